@@ -0,0 +1,62 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	hookv1 "github.com/triggermesh/scoby/pkg/apis/hook/v1"
+	"github.com/triggermesh/scoby/pkg/apis/hook/v1/hookpb"
+)
+
+// GRPCHandlerV1 serves the hook gRPC service re-using the
+// HTTP handler implementation.
+type GRPCHandlerV1 struct {
+	hookpb.UnimplementedHookServer
+
+	h *HandlerV1
+}
+
+func (g *GRPCHandlerV1) Reconcile(ctx context.Context, req *hookpb.HookRequest) (*hookpb.HookResponse, error) {
+	hreq := hookpb.ToHookRequest(req)
+
+	w := httptest.NewRecorder()
+	switch hreq.FormFactor.Name {
+	case "deployment":
+		g.h.ServeDeploymentHook(w, hreq)
+
+	case "ksvc":
+		g.h.ServeKsvcHook(w, hreq)
+
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "request for formfactor %q not supported", hreq.FormFactor.Name)
+	}
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		herr := &hookv1.HookResponseError{}
+		if err := json.NewDecoder(res.Body).Decode(herr); err != nil {
+			return nil, status.Error(codes.InvalidArgument, w.Body.String())
+		}
+
+		return &hookpb.HookResponse{Error: hookpb.NewHookResponseError(herr)}, nil
+	}
+
+	hres := &hookv1.HookResponse{}
+	if res.StatusCode == http.StatusOK && w.Body.Len() != 0 {
+		if err := json.NewDecoder(res.Body).Decode(hres); err != nil {
+			return nil, status.Errorf(codes.Internal, "could not decode response: %v", err)
+		}
+	}
+
+	return hookpb.NewHookResponse(hres)
+}
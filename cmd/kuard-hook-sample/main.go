@@ -11,9 +11,13 @@ import (
 	"html"
 	"log"
 	"math/rand"
+	"net"
 	"net/http"
+	"os"
 	"time"
 
+	"google.golang.org/grpc"
+
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -22,6 +26,7 @@ import (
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
 
 	hookv1 "github.com/triggermesh/scoby/pkg/apis/hook/v1"
+	"github.com/triggermesh/scoby/pkg/apis/hook/v1/hookpb"
 )
 
 const (
@@ -35,6 +40,12 @@ func main() {
 		rnd: rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 
+	// The hook can be served using gRPC instead of HTTP.
+	if os.Getenv("HOOK_TRANSPORT") == "grpc" {
+		serveGRPC(h)
+		return
+	}
+
 	mux := http.NewServeMux()
 	mux.Handle("/v1/", h)
 	mux.Handle("/v1", h)
@@ -60,6 +71,21 @@ func main() {
 	}
 }
 
+func serveGRPC(h *HandlerV1) {
+	lis, err := net.Listen("tcp", ":8080")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	srv := grpc.NewServer()
+	hookpb.RegisterHookServer(srv, &GRPCHandlerV1{h: h})
+
+	log.Println("starting kuard gRPC hook")
+	if err := srv.Serve(lis); err != nil {
+		log.Fatal(err)
+	}
+}
+
 // HandlerV1 is an example hooks server.
 type HandlerV1 struct {
 	rnd *rand.Rand
@@ -111,8 +111,10 @@ func main() {
 	}
 	cmr := configmap.NewNamespacedReader(scobyconfig.Get().ScobyNamespace(), sc)
 
-	// Builder for component reconcilers
-	crb := crbuilder.NewBuilder(mgr, reslv, cmr)
+	// Builder for component reconcilers, pull secrets at the Scoby
+	// namespace are read using the standalone client.
+	crb := crbuilder.NewBuilder(mgr, reslv, cmr,
+		crbuilder.WithScobyNamespaceReader(sc, scobyconfig.Get().ScobyNamespace()))
 
 	// Parent context.
 	ctx := ctrl.SetupSignalHandler()
@@ -120,10 +122,12 @@ func main() {
 	cl := log.WithName("component")
 	reg := registry.New(ctx, crb, &cl)
 
-	r := crd.New(mgr.GetClient(), reg, reslv, cl.WithName("crdregistration"))
+	r := crd.New(mgr.GetClient(), reg, reslv, cl.WithName("crdregistration"),
+		crd.WithWorkingNamespaces(scobyconfig.Get().WorkingNamespaces()))
 
 	if err := builder.ControllerManagedBy(mgr).
 		For(&scobyv1alpha1.CRDRegistration{}).
+		Owns(&apiextensionsv1.CustomResourceDefinition{}).
 		Complete(r); err != nil {
 		log.Error(err, "could not build controller for CRD registration")
 		os.Exit(1)
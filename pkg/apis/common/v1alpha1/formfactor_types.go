@@ -3,6 +3,12 @@
 
 package v1alpha1
 
+import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
 // FormFactor contains workload form factor settings.
 type FormFactor struct {
 	// Deployment hosting the user workload.
@@ -13,19 +19,242 @@ type FormFactor struct {
 
 // DeploymentFormFactor contains parameters for Deployment choice.
 type DeploymentFormFactor struct {
-	// Replicas for the deployment.
+	// Replicas for the deployment, not used when autoscaling is enabled.
+	// +optional
 	Replicas int `json:"replicas"`
 
+	// Autoscaling creates a HorizontalPodAutoscaler that manages
+	// the deployment replicas.
+	// +optional
+	Autoscaling *DeploymentAutoscaling `json:"autoscaling,omitempty"`
+
+	// PodDisruptionBudget creates a PodDisruptionBudget for
+	// the deployment pods.
+	// +optional
+	PodDisruptionBudget *DeploymentPodDisruptionBudget `json:"podDisruptionBudget,omitempty"`
+
 	// Service to create pointing to the deployment.
 	// +optional
 	Service *DeploymentService `json:"service"`
+
+	// PodDiagnostics inspects the ReplicaSets and Pods of non ready
+	// deployments to inform the failure reason at the status.
+	// +optional
+	PodDiagnostics bool `json:"podDiagnostics,omitempty"`
+}
+
+// DeploymentAutoscaling contains the HorizontalPodAutoscaler settings.
+type DeploymentAutoscaling struct {
+	// MinReplicas for the deployment, defaults to 1.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas for the deployment.
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// TargetCPUUtilizationPercentage is the average CPU utilization
+	// across pods, relative to the requested CPU.
+	// +optional
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+
+	// TargetMemoryUtilizationPercentage is the average memory utilization
+	// across pods, relative to the requested memory.
+	// +optional
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+
+	// Metrics are custom metrics used along the CPU and memory
+	// targets.
+	// +optional
+	Metrics []autoscalingv2.MetricSpec `json:"metrics,omitempty"`
+
+	// FromSpec overrides the replicas range using elements at
+	// the instance spec.
+	// +optional
+	FromSpec *AutoscalingFromSpec `json:"fromSpec,omitempty"`
 }
 
+// AutoscalingFromSpec contains the paths of instance spec elements that
+// override the replicas range. Elements are not rendered as parameters.
+type AutoscalingFromSpec struct {
+	// MinReplicas path, the element must be an integer.
+	// +optional
+	MinReplicas string `json:"minReplicas,omitempty"`
+
+	// MaxReplicas path, the element must be an integer.
+	// +optional
+	MaxReplicas string `json:"maxReplicas,omitempty"`
+}
+
+// DeploymentPodDisruptionBudget contains the PodDisruptionBudget settings,
+// only one of MinAvailable or MaxUnavailable can be set.
+type DeploymentPodDisruptionBudget struct {
+	// MinAvailable pods after an eviction.
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// MaxUnavailable pods after an eviction.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// DeploymentService contains the settings for the service pointing
+// to the deployment.
 type DeploymentService struct {
+	// Port exposed at the service, not used when ports are informed.
+	// +optional
+	Port int32 `json:"port,omitempty"`
+	// Port exposed at the target deployment, not used when ports
+	// are informed.
+	// +optional
+	TargetPort int32 `json:"targetPort,omitempty"`
+
+	// Ports exposed at the service.
+	// +optional
+	Ports []DeploymentServicePort `json:"ports,omitempty"`
+
+	// Type of the service, defaults to ClusterIP.
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	Type corev1.ServiceType `json:"type,omitempty"`
+
+	// Headless creates a service without a cluster IP that resolves
+	// to the pods addresses. Only valid for ClusterIP services.
+	// +optional
+	Headless bool `json:"headless,omitempty"`
+
+	// Address selects the port and scheme used for the URL informed
+	// at the instance status.
+	// +optional
+	Address *DeploymentServiceAddress `json:"address,omitempty"`
+
+	// Expose routes requests from outside the cluster to the service
+	// using an Ingress or a Gateway API HTTPRoute.
+	// +optional
+	Expose *DeploymentServiceExpose `json:"expose,omitempty"`
+}
+
+// DeploymentServicePort is a port exposed at the service.
+type DeploymentServicePort struct {
+	// Name of the port, required when exposing multiple ports.
+	// +optional
+	Name string `json:"name,omitempty"`
 	// Port exposed at the service.
 	Port int32 `json:"port"`
-	// Port exposed at the target deployment.
-	TargetPort int32 `json:"targetPort"`
+	// Port exposed at the target deployment, defaults to the
+	// service port.
+	// +optional
+	TargetPort int32 `json:"targetPort,omitempty"`
+	// Protocol for the port, defaults to TCP.
+	// +optional
+	// +kubebuilder:validation:Enum=TCP;UDP;SCTP
+	Protocol corev1.Protocol `json:"protocol,omitempty"`
+	// NodePort for NodePort and LoadBalancer services, allocated
+	// when not informed.
+	// +optional
+	NodePort int32 `json:"nodePort,omitempty"`
+}
+
+// DeploymentServiceAddress selects how the address URL is composed.
+type DeploymentServiceAddress struct {
+	// Port is the name of the port used for the URL, defaults
+	// to the first port.
+	// +optional
+	Port string `json:"port,omitempty"`
+	// Scheme for the URL, defaults to http.
+	// +optional
+	Scheme string `json:"scheme,omitempty"`
+}
+
+// DeploymentServiceExpose configures the object that routes external
+// requests to the service. Exactly one of ingress or httpRoute must
+// be informed.
+type DeploymentServiceExpose struct {
+	// Host is a Go template for the exposed host name that can use
+	// the instance .Name, .Namespace and .Spec elements, like
+	// {{.Name}}.{{.Namespace}}.example.com
+	Host string `json:"host"`
+	// Path prefix routed to the service, defaults to /.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^/`
+	Path string `json:"path,omitempty"`
+	// Port is the name of the service port that receives the
+	// requests, defaults to the first port.
+	// +optional
+	Port string `json:"port,omitempty"`
+	// TLS serves the host using HTTPS.
+	// +optional
+	TLS *DeploymentServiceExposeTLS `json:"tls,omitempty"`
+
+	// Ingress exposes the service using a Kubernetes Ingress.
+	// +optional
+	Ingress *DeploymentServiceIngress `json:"ingress,omitempty"`
+	// HTTPRoute exposes the service using a Gateway API HTTPRoute.
+	// +optional
+	HTTPRoute *DeploymentServiceHTTPRoute `json:"httpRoute,omitempty"`
+}
+
+// DeploymentServiceExposeTLS configures HTTPS for the exposed host.
+type DeploymentServiceExposeTLS struct {
+	// SecretName is a Go template for the name of the secret that
+	// contains the host certificate, using the same elements as the
+	// host. Only used by Ingresses, HTTPRoutes reference certificates
+	// at the Gateway listener. When not informed the Ingress controller
+	// default certificate is used.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// DeploymentServiceIngress contains parameters for the generated Ingress.
+type DeploymentServiceIngress struct {
+	// ClassName of the Ingress controller, defaults to the cluster
+	// default class.
+	// +optional
+	ClassName *string `json:"className,omitempty"`
+	// Annotations added to the Ingress, usually to configure
+	// the Ingress controller.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// DeploymentServiceHTTPRoute contains parameters for the generated HTTPRoute.
+type DeploymentServiceHTTPRoute struct {
+	// ParentRefs are the Gateways the route attaches to.
+	// +kubebuilder:validation:MinItems=1
+	ParentRefs []HTTPRouteParentReference `json:"parentRefs"`
+}
+
+// HTTPRouteParentReference identifies a Gateway listener.
+type HTTPRouteParentReference struct {
+	// Name of the Gateway.
+	Name string `json:"name"`
+	// Namespace of the Gateway, defaults to the instance namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// SectionName is the name of the Gateway listener, defaults
+	// to all listeners.
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// GetPorts returns the ports exposed at the service.
+func (ds *DeploymentService) GetPorts() []DeploymentServicePort {
+	if len(ds.Ports) != 0 {
+		return ds.Ports
+	}
+	return []DeploymentServicePort{{
+		Port:       ds.Port,
+		TargetPort: ds.TargetPort,
+	}}
+}
+
+// GetTargetPort returns the port at the deployment.
+func (dsp *DeploymentServicePort) GetTargetPort() int32 {
+	if dsp.TargetPort == 0 {
+		return dsp.Port
+	}
+	return dsp.TargetPort
 }
 
 // KnativeServiceFormFactor contains parameters for Deployment choice.
@@ -39,4 +268,82 @@ type KnativeServiceFormFactor struct {
 	// Visibility is the network visibility for the service
 	// +optional
 	Visibility *string `json:"visibility,omitempty"`
+
+	// InitialScale is the number of replicas a revision starts with.
+	// +optional
+	InitialScale *int `json:"initialScale,omitempty"`
+	// ScaleDownDelay is the time to wait at reduced concurrency
+	// before scaling down, like 15m.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ms|s|m|h))+$`
+	ScaleDownDelay *string `json:"scaleDownDelay,omitempty"`
+	// Autoscaling customizes the revisions autoscaler.
+	// +optional
+	Autoscaling *KnativeServiceAutoscaling `json:"autoscaling,omitempty"`
+
+	// ContainerConcurrency is the maximum number of concurrent
+	// requests per replica, 0 means unlimited.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	ContainerConcurrency *int64 `json:"containerConcurrency,omitempty"`
+	// TimeoutSeconds is the maximum duration of a request.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	TimeoutSeconds *int64 `json:"timeoutSeconds,omitempty"`
+	// ResponseStartTimeoutSeconds is the maximum duration to wait
+	// for the response to start after a request is received.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	ResponseStartTimeoutSeconds *int64 `json:"responseStartTimeoutSeconds,omitempty"`
+
+	// RevisionName is a template for the revisions name, which must
+	// start with the service name and include the revision template
+	// hash. The template can use .ServiceName, .Name, .Namespace,
+	// .Generation and .Hash, like {{.ServiceName}}-{{.Hash}}.
+	// +optional
+	RevisionName string `json:"revisionName,omitempty"`
+	// Traffic splits requests among revisions. Defaults to sending
+	// all traffic to the latest revision.
+	// +optional
+	Traffic []KnativeServiceTrafficTarget `json:"traffic,omitempty"`
+
+	// PodDiagnostics inspects the latest Revision and its Pods of non
+	// ready knative services to inform the failure reason at the status.
+	// +optional
+	PodDiagnostics bool `json:"podDiagnostics,omitempty"`
+}
+
+// KnativeServiceAutoscaling contains the revisions autoscaler settings.
+type KnativeServiceAutoscaling struct {
+	// Class of the autoscaler.
+	// +optional
+	// +kubebuilder:validation:Enum=kpa.autoscaling.knative.dev;hpa.autoscaling.knative.dev
+	Class string `json:"class,omitempty"`
+	// Metric that the autoscaler targets.
+	// +optional
+	// +kubebuilder:validation:Enum=concurrency;rps;cpu;memory
+	Metric string `json:"metric,omitempty"`
+	// Target value for the metric per replica.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Target *int `json:"target,omitempty"`
+}
+
+// KnativeServiceTrafficTarget sends a percent of traffic to a revision.
+type KnativeServiceTrafficTarget struct {
+	// RevisionName pins traffic to a revision, which must exist.
+	// +optional
+	RevisionName string `json:"revisionName,omitempty"`
+	// LatestRevision sends traffic to the latest ready revision,
+	// cannot be set along with the revision name.
+	// +optional
+	LatestRevision *bool `json:"latestRevision,omitempty"`
+	// Percent of traffic sent to the target.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Percent *int64 `json:"percent,omitempty"`
+	// Tag exposes the target at a dedicated URL.
+	// +optional
+	Tag string `json:"tag,omitempty"`
 }
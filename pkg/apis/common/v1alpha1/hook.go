@@ -11,6 +11,16 @@ type HookCapabilities []hookv1.Phase
 
 type HookAPIVersion string
 
+// HookTransport is the protocol used to communicate with hooks.
+type HookTransport string
+
+const (
+	// HookTransportHTTP sends JSON requests over HTTP to the hook.
+	HookTransportHTTP HookTransport = "http"
+	// HookTransportGRPC uses the gRPC Hook service defined at the hookpb package.
+	HookTransportGRPC HookTransport = "grpc"
+)
+
 const (
 	// Status annotation name for the resolved Hook URL
 	CRDRegistrationAnnotationHookURL = "hookURL"
@@ -27,6 +37,60 @@ type Hook struct {
 
 	// Capabilities that a hook implements.
 	Capabilities HookCapabilities `json:"capabilities,omitempty"`
+
+	// Transport used for hook calls, either http or grpc. Defaults to http.
+	// +optional
+	// +kubebuilder:validation:Enum=http;grpc
+	Transport *HookTransport `json:"transport,omitempty"`
+
+	// Retry policy for hook calls that fail due to transient errors.
+	// +optional
+	Retry *HookRetry `json:"retry,omitempty"`
+
+	// CircuitBreaker stops calling the hook after repeated failures.
+	// +optional
+	CircuitBreaker *HookCircuitBreaker `json:"circuitBreaker,omitempty"`
+}
+
+// HookRetry configures retries for failed hook calls.
+type HookRetry struct {
+	// Attempts is the number of retries after the first failed call.
+	// +optional
+	Attempts *int32 `json:"attempts,omitempty"`
+
+	// BackoffDelay is the ISO 8601 duration used as the base delay for
+	// the exponential backoff between retries.
+	// +optional
+	BackoffDelay *string `json:"backoffDelay,omitempty"`
+
+	// MaxBackoffDelay is the ISO 8601 duration that caps the
+	// delay between retries.
+	// +optional
+	MaxBackoffDelay *string `json:"maxBackoffDelay,omitempty"`
+
+	// Jitter randomizes the delay between retries. Defaults to true.
+	// +optional
+	Jitter *bool `json:"jitter,omitempty"`
+}
+
+// HookCircuitBreaker configures the circuit breaker for hook calls.
+type HookCircuitBreaker struct {
+	// FailureThreshold is the number of consecutive failed calls
+	// that opens the circuit.
+	FailureThreshold int32 `json:"failureThreshold"`
+
+	// ResetTimeout is the ISO 8601 duration the circuit stays open
+	// before a new call is allowed.
+	// +optional
+	ResetTimeout *string `json:"resetTimeout,omitempty"`
+}
+
+// GetTransport returns the configured transport for the hook, defaulting to HTTP.
+func (h *Hook) GetTransport() HookTransport {
+	if h.Transport == nil || *h.Transport == "" {
+		return HookTransportHTTP
+	}
+	return *h.Transport
 }
 
 func (hc HookCapabilities) IsFinalizer() bool {
@@ -93,6 +93,43 @@ type Status struct {
 	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
+// ComponentStatus summarizes the controller running for a registration
+// and the instances it manages.
+type ComponentStatus struct {
+	// CRDVersion is the CRD version served by the controller.
+	// +optional
+	CRDVersion string `json:"crdVersion,omitempty"`
+
+	// FormFactor is the workload form factor, deployment or ksvc.
+	// +optional
+	FormFactor string `json:"formFactor,omitempty"`
+
+	// StatusCapabilities are the instance status elements supported
+	// by the CRD schema.
+	// +optional
+	StatusCapabilities []string `json:"statusCapabilities,omitempty"`
+
+	// HookCapabilities are the active hook capabilities.
+	// +optional
+	HookCapabilities []string `json:"hookCapabilities,omitempty"`
+
+	// Namespaces watched by the controller. When empty all
+	// namespaces are watched.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Instances is the number of instances known by the controller.
+	Instances int `json:"instances"`
+
+	// ReadyInstances is the number of ready instances.
+	ReadyInstances int `json:"readyInstances"`
+
+	// LastRenderError is the last rendering error seen across
+	// instances, prefixed by the instance namespace and name.
+	// +optional
+	LastRenderError string `json:"lastRenderError,omitempty"`
+}
+
 // Time is a helper wrap around time.Now that
 // enables us to write tests.
 // +kubebuilder:object:generate=false
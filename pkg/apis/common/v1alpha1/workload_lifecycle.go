@@ -28,3 +28,15 @@ func (dv *SpecToEnvDefaultValue) ToEnv(name string) *corev1.EnvVar {
 
 	return ev
 }
+
+// Image returns the image reference, composed using the digest
+// or tag when informed.
+func (f *RegistrationFromImage) Image() string {
+	switch {
+	case f.Digest != "":
+		return f.Repo + "@" + f.Digest
+	case f.Tag != "":
+		return f.Repo + ":" + f.Tag
+	}
+	return f.Repo
+}
@@ -5,6 +5,7 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 )
 
 // Workload contains workload settings.
@@ -15,6 +16,27 @@ type Workload struct {
 	FormFactor *FormFactor `json:"formFactor,omitempty"`
 	// FromImage contains the container image information.
 	FromImage RegistrationFromImage `json:"fromImage"`
+	// Container customizes the workload container.
+	// +optional
+	Container *ContainerConfiguration `json:"container,omitempty"`
+	// ServiceAccount creates the ServiceAccount the workload runs as,
+	// along with its permissions.
+	// +optional
+	ServiceAccount *ServiceAccountConfiguration `json:"serviceAccount,omitempty"`
+	// Scheduling customizes where the workload pods are scheduled.
+	// +optional
+	Scheduling *SchedulingConfiguration `json:"scheduling,omitempty"`
+	// SecurityContext customizes the workload pod and container
+	// security contexts.
+	// +optional
+	SecurityContext *SecurityConfiguration `json:"securityContext,omitempty"`
+	// InitContainers run to completion before the workload container
+	// starts.
+	// +optional
+	InitContainers []AdditionalContainer `json:"initContainers,omitempty"`
+	// Sidecars run along the workload container.
+	// +optional
+	Sidecars []AdditionalContainer `json:"sidecars,omitempty"`
 	// ParameterConfiguration sets how object elements
 	// are transformed into workload parameters.
 	// +optional
@@ -23,12 +45,285 @@ type Workload struct {
 	// a controlled instance status.
 	// +optional
 	StatusConfiguration *StatusConfiguration `json:"statusConfiguration,omitempty"`
+	// VersionConfiguration selects the CRD version watched by the
+	// controller and customizes rendering per version.
+	// +optional
+	VersionConfiguration *VersionConfiguration `json:"versionConfiguration,omitempty"`
 }
 
 // RegistrationFromImage contains information to retrieve the container image.
 type RegistrationFromImage struct {
-	// Repo where the image can be downloaded
+	// Repo where the image can be downloaded, it might include
+	// the tag or digest.
 	Repo string `json:"repo"`
+
+	// Tag of the image.
+	// +optional
+	Tag string `json:"tag,omitempty"`
+
+	// Digest of the image, takes precedence over the tag.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]+:[a-f0-9]+$`
+	Digest string `json:"digest,omitempty"`
+
+	// PullPolicy for the image.
+	// +optional
+	// +kubebuilder:validation:Enum=Always;IfNotPresent;Never
+	PullPolicy corev1.PullPolicy `json:"pullPolicy,omitempty"`
+
+	// PullSecrets used for pulling the image.
+	// +optional
+	PullSecrets []ImagePullSecret `json:"pullSecrets,omitempty"`
+
+	// FromSpec overrides the image using an element at
+	// the instance spec.
+	// +optional
+	FromSpec *ImageFromSpec `json:"fromSpec,omitempty"`
+}
+
+// ServiceAccountScope sets which instances share a ServiceAccount.
+type ServiceAccountScope string
+
+const (
+	// ServiceAccountScopeInstance creates a ServiceAccount for each instance.
+	ServiceAccountScopeInstance ServiceAccountScope = "Instance"
+	// ServiceAccountScopeNamespace creates a ServiceAccount shared by
+	// the registration instances at each namespace.
+	ServiceAccountScopeNamespace ServiceAccountScope = "Namespace"
+)
+
+// ServiceAccountConfiguration contains the ServiceAccount settings
+// for the workload.
+type ServiceAccountConfiguration struct {
+	// Scope of the ServiceAccount, either one per Instance or
+	// one per Namespace shared by the registration instances.
+	// +optional
+	// +kubebuilder:validation:Enum=Instance;Namespace
+	// +kubebuilder:default=Instance
+	Scope ServiceAccountScope `json:"scope,omitempty"`
+
+	// Annotations added to the ServiceAccount, used for cloud
+	// identity integrations.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// ClusterRole bound to the ServiceAccount at the namespace.
+	// +optional
+	ClusterRole string `json:"clusterRole,omitempty"`
+
+	// Rules for a Role generated at the namespace and bound
+	// to the ServiceAccount.
+	// +optional
+	Rules []rbacv1.PolicyRule `json:"rules,omitempty"`
+}
+
+// ImagePullSecret references a secret for pulling images.
+type ImagePullSecret struct {
+	// Name of the secret.
+	Name string `json:"name"`
+
+	// CopyFromScobyNamespace copies the secret at the Scoby namespace
+	// into the instance namespace. When false the secret must exist
+	// at the instance namespace.
+	// +optional
+	CopyFromScobyNamespace bool `json:"copyFromScobyNamespace,omitempty"`
+}
+
+// ImageFromSpec overrides the image using an instance spec element.
+type ImageFromSpec struct {
+	// Path of the instance spec element that contains the image.
+	Path string `json:"path"`
+
+	// AllowedRegistries the image at the instance must be pulled from,
+	// each item is a registry host optionally followed by a path, like
+	// gcr.io/triggermesh.
+	// +kubebuilder:validation:MinItems=1
+	AllowedRegistries []string `json:"allowedRegistries"`
+}
+
+// ContainerConfiguration contains settings for the workload container
+// and the instance spec elements that override them.
+type ContainerConfiguration struct {
+	ContainerSettings `json:",inline"`
+
+	// FromSpec overrides container settings using elements at
+	// the instance spec.
+	// +optional
+	FromSpec *ContainerFromSpec `json:"fromSpec,omitempty"`
+}
+
+// ContainerSettings for the workload container.
+type ContainerSettings struct {
+	// Resources required by the container.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Ports exposed by the container.
+	// +optional
+	Ports []corev1.ContainerPort `json:"ports,omitempty"`
+
+	// LivenessProbe for the container.
+	// +optional
+	LivenessProbe *corev1.Probe `json:"livenessProbe,omitempty"`
+
+	// ReadinessProbe for the container.
+	// +optional
+	ReadinessProbe *corev1.Probe `json:"readinessProbe,omitempty"`
+
+	// StartupProbe for the container.
+	// +optional
+	StartupProbe *corev1.Probe `json:"startupProbe,omitempty"`
+
+	// Lifecycle hooks for the container.
+	// +optional
+	Lifecycle *corev1.Lifecycle `json:"lifecycle,omitempty"`
+}
+
+// AdditionalContainer is a container that runs along the workload
+// container at the workload pods.
+type AdditionalContainer struct {
+	// Name of the container, must be unique at the workload and
+	// different from the workload container name, "adapter".
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Image for the container.
+	Image string `json:"image"`
+
+	// PullPolicy for the image.
+	// +optional
+	// +kubebuilder:validation:Enum=Always;IfNotPresent;Never
+	PullPolicy corev1.PullPolicy `json:"pullPolicy,omitempty"`
+
+	// Command to run instead of the image entrypoint.
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// Args for the command.
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// Env variables for the container.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Container settings, probes and lifecycle are not allowed
+	// for init containers.
+	ContainerSettings `json:",inline"`
+
+	// SecurityContext for the container, defaults to the workload
+	// container security context.
+	// +optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+
+	// ShareRendered adds the environment variables and volume mounts
+	// rendered from the instance to the container, unless the
+	// rendering rule selects target containers.
+	// +optional
+	ShareRendered bool `json:"shareRendered,omitempty"`
+}
+
+// ContainerFromSpec contains the paths of instance spec elements that
+// override container settings, like spec.resources. Elements are not
+// rendered as parameters. Settings are not overridden when the element
+// does not exist at the instance.
+type ContainerFromSpec struct {
+	// Resources path, the element must be a ResourceRequirements object.
+	// +optional
+	Resources string `json:"resources,omitempty"`
+
+	// LivenessProbe path, the element must be a Probe object.
+	// +optional
+	LivenessProbe string `json:"livenessProbe,omitempty"`
+
+	// ReadinessProbe path, the element must be a Probe object.
+	// +optional
+	ReadinessProbe string `json:"readinessProbe,omitempty"`
+
+	// StartupProbe path, the element must be a Probe object.
+	// +optional
+	StartupProbe string `json:"startupProbe,omitempty"`
+}
+
+// SchedulingConfiguration contains scheduling settings for the workload
+// pods and the instance spec elements that override them.
+type SchedulingConfiguration struct {
+	SchedulingSettings `json:",inline"`
+
+	// FromSpec overrides scheduling settings using elements at
+	// the instance spec.
+	// +optional
+	FromSpec *SchedulingFromSpec `json:"fromSpec,omitempty"`
+}
+
+// SchedulingSettings for the workload pods.
+type SchedulingSettings struct {
+	// NodeSelector labels that nodes must match.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Affinity scheduling rules.
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// Tolerations for node taints.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// TopologySpreadConstraints for spreading pods across
+	// topology domains.
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// PriorityClassName for the pods.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// SchedulingFromSpec contains the paths of instance spec elements that
+// override scheduling settings, like spec.nodeSelector. Elements are not
+// rendered as parameters. Settings are not overridden when the element
+// does not exist at the instance.
+type SchedulingFromSpec struct {
+	// NodeSelector path, the element must be a map of strings.
+	// +optional
+	NodeSelector string `json:"nodeSelector,omitempty"`
+
+	// Affinity path, the element must be an Affinity object.
+	// +optional
+	Affinity string `json:"affinity,omitempty"`
+
+	// Tolerations path, the element must be an array of Tolerations.
+	// +optional
+	Tolerations string `json:"tolerations,omitempty"`
+
+	// TopologySpreadConstraints path, the element must be an array
+	// of TopologySpreadConstraints.
+	// +optional
+	TopologySpreadConstraints string `json:"topologySpreadConstraints,omitempty"`
+
+	// PriorityClassName path, the element must be a string.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// SecurityConfiguration contains the security settings for the workload.
+type SecurityConfiguration struct {
+	// Pod security context. When not informed no pod security
+	// context is set.
+	// +optional
+	Pod *corev1.PodSecurityContext `json:"pod,omitempty"`
+
+	// Container security context. When not informed a context compliant
+	// with the restricted Pod Security Standard is used.
+	// +optional
+	Container *corev1.SecurityContext `json:"container,omitempty"`
+
+	// WritableTmp mounts an emptyDir volume at /tmp, for images that
+	// need to write temporary files when using a read only root
+	// filesystem.
+	// +optional
+	WritableTmp bool `json:"writableTmp,omitempty"`
 }
 
 // ParameterConfiguration for the workload.
@@ -98,6 +393,10 @@ type AddToEnvConfiguration struct {
 	// read from a ConfigMap at the controller's namespace.
 	// +optional
 	ValueFromControllerConfigMap *corev1.ConfigMapKeySelector `json:"valueFromControllerConfigMap,omitempty"`
+
+	// Containers that the environment variable is added to.
+	// +optional
+	Containers ContainerSelector `json:"containers,omitempty"`
 }
 
 // Instructions to extract envrionment variables values from the object's registration.
@@ -209,6 +508,10 @@ type FromSpecToEnv struct {
 	// ValueFrom uses a .
 	// +optional
 	ValueFrom *SpecToEnvValueFrom `json:"valueFrom,omitempty"`
+
+	// Containers that the environment variable is added to.
+	// +optional
+	Containers ContainerSelector `json:"containers,omitempty"`
 }
 
 // FromSpecToVolume are the customization options for a volume
@@ -225,6 +528,31 @@ type FromSpecToVolume struct {
 
 	// ValueFrom references an object to mount.
 	MountFrom MountFrom `json:"mountFrom,omitempty"`
+
+	// Containers that the volume is mounted at.
+	// +optional
+	Containers ContainerSelector `json:"containers,omitempty"`
+}
+
+// ContainerSelector contains the names of the containers targeted by a
+// rendering rule, where "adapter" is the workload container. When empty
+// the workload container and those sharing rendered elements are selected.
+type ContainerSelector []string
+
+// Selects returns whether the container is targeted by the selector. The
+// share flag informs if the container receives rendered elements when not
+// explicitly selected.
+func (cs ContainerSelector) Selects(container string, share bool) bool {
+	if len(cs) == 0 {
+		return share
+	}
+
+	for _, c := range cs {
+		if c == container {
+			return true
+		}
+	}
+	return false
 }
 
 func (fsc *FromSpecConfiguration) IsRenderer() bool {
@@ -281,6 +609,52 @@ type StatusConfiguration struct {
 	// ConditionsFromHook contains conditions expected to be informed from the Hook.
 	// +optional
 	ConditionsFromHook []ConditionsFromHook `json:"conditionsFromHook,omitempty"`
+
+	// HappyCondition is the condition type that summarizes the
+	// rest of conditions. Defaults to Ready.
+	// +optional
+	HappyCondition string `json:"happyCondition,omitempty"`
+
+	// AddressURLPath is the JSON simplified path for the status
+	// element that is filled with the workload's URL.
+	// Defaults to status.address.url.
+	// +optional
+	AddressURLPath string `json:"addressURLPath,omitempty"`
+
+	// PublicURLPath is the JSON simplified path for the status
+	// element that is filled with the workload's URL when exposed
+	// outside the cluster.
+	// Defaults to status.url.
+	// +optional
+	PublicURLPath string `json:"publicURLPath,omitempty"`
+
+	// Phase configures a status element that is filled with a
+	// value derived from the happy condition.
+	// +optional
+	Phase *StatusPhase `json:"phase,omitempty"`
+}
+
+// StatusPhase is a status element that summarizes the happy condition
+// for CRDs that inform their state using a single value.
+type StatusPhase struct {
+	// JSON simplified path for the phase status element,
+	// like status.phase or status.state.
+	Path string `json:"path"`
+
+	// Ready is the value used when the happy condition is True.
+	// Defaults to Ready.
+	// +optional
+	Ready string `json:"ready,omitempty"`
+
+	// NotReady is the value used when the happy condition is False.
+	// Defaults to NotReady.
+	// +optional
+	NotReady string `json:"notReady,omitempty"`
+
+	// Unknown is the value used when the happy condition is Unknown.
+	// Defaults to Unknown.
+	// +optional
+	Unknown string `json:"unknown,omitempty"`
 }
 
 // StatusAddElement is a customization option that adds or fills an element
@@ -296,8 +670,26 @@ type StatusAddElement struct {
 
 // StatusValueFrom contains references to elements that
 // can be used to fill the status.
+// +kubebuilder:validation:XValidation:rule="has(self.path) != has(self.child)",message="either path or child must be informed"
 type StatusValueFrom struct {
 	// JSON simplified path for the referenced element.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Child references an element at a reconciled child object.
+	// +optional
+	Child *StatusValueFromChild `json:"child,omitempty"`
+}
+
+// StatusValueFromChild references an element at a child object
+// generated for the instance.
+type StatusValueFromChild struct {
+	// Name of the child, which is the key at the children map, like
+	// deployment, service or ksvc.
+	Name string `json:"name"`
+
+	// JSON simplified path for the referenced element at the child
+	// object. Array items can be referenced using their index.
 	Path string `json:"path"`
 }
 
@@ -306,4 +698,92 @@ type StatusValueFrom struct {
 type ConditionsFromHook struct {
 	// Type of the condition to be informed.
 	Type string `json:"type"`
+
+	// Severity of the condition. Only conditions with Error
+	// severity are aggregated into the happy condition.
+	// Defaults to Error.
+	// +optional
+	Severity ConditionSeverity `json:"severity,omitempty"`
+}
+
+// ConditionSeverity expresses how much a condition contributes
+// to the object's happiness.
+// +kubebuilder:validation:Enum=Error;Warning;Info
+type ConditionSeverity string
+
+const (
+	// ConditionSeverityError conditions are aggregated into the
+	// happy condition.
+	ConditionSeverityError ConditionSeverity = "Error"
+	// ConditionSeverityWarning conditions are informational and
+	// signal situations that need attention.
+	ConditionSeverityWarning ConditionSeverity = "Warning"
+	// ConditionSeverityInfo conditions are informational.
+	ConditionSeverityInfo ConditionSeverity = "Info"
+)
+
+// VersionConfiguration selects the CRD version and contains rendering
+// rules for CRD versions.
+type VersionConfiguration struct {
+	// Selection of the CRD version watched by the controller.
+	// Defaults to Preferred.
+	// +optional
+	Selection VersionSelection `json:"selection,omitempty"`
+
+	// Rules customize rendering for CRD versions.
+	// +optional
+	Rules []VersionRule `json:"rules,omitempty"`
+}
+
+// VersionSelection is the policy for choosing the CRD version.
+// +kubebuilder:validation:Enum=Preferred;Storage
+type VersionSelection string
+
+const (
+	// VersionSelectionPreferred selects the highest served version
+	// that is not deprecated.
+	VersionSelectionPreferred VersionSelection = "Preferred"
+	// VersionSelectionStorage selects the storage version.
+	VersionSelectionStorage VersionSelection = "Storage"
+)
+
+// VersionRule contains rendering rules for a CRD version.
+type VersionRule struct {
+	// Version of the CRD the rule applies to.
+	Version string `json:"version"`
+
+	// ParameterConfiguration replaces the workload's parameter
+	// configuration when the version is in use.
+	// +optional
+	ParameterConfiguration *ParameterConfiguration `json:"parameterConfiguration,omitempty"`
+
+	// FieldMappings move elements from paths used at other versions
+	// to the paths used at this version before rendering.
+	// +optional
+	FieldMappings []FieldMapping `json:"fieldMappings,omitempty"`
+}
+
+// FieldMapping moves an element at the instance spec. Mappings are
+// only applied when the source element exists and the target does not.
+type FieldMapping struct {
+	// From is the path of the element at other versions.
+	From string `json:"from"`
+
+	// To is the path of the element at this version.
+	To string `json:"to"`
+}
+
+// GetVersionRule returns the rule for the CRD version, if any.
+func (w *Workload) GetVersionRule(version string) *VersionRule {
+	if w.VersionConfiguration == nil {
+		return nil
+	}
+
+	for i := range w.VersionConfiguration.Rules {
+		if w.VersionConfiguration.Rules[i].Version == version {
+			return &w.VersionConfiguration.Rules[i]
+		}
+	}
+
+	return nil
 }
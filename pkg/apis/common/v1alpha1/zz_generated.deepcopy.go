@@ -9,7 +9,10 @@
 package v1alpha1
 
 import (
-	"k8s.io/api/core/v1"
+	"k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"knative.dev/pkg/apis"
 )
 
@@ -57,9 +60,14 @@ func (in *AddToEnvConfiguration) DeepCopyInto(out *AddToEnvConfiguration) {
 	}
 	if in.ValueFromControllerConfigMap != nil {
 		in, out := &in.ValueFromControllerConfigMap, &out.ValueFromControllerConfigMap
-		*out = new(v1.ConfigMapKeySelector)
+		*out = new(corev1.ConfigMapKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make(ContainerSelector, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddToEnvConfiguration.
@@ -77,17 +85,17 @@ func (in *AddToEnvValueFrom) DeepCopyInto(out *AddToEnvValueFrom) {
 	*out = *in
 	if in.ConfigMap != nil {
 		in, out := &in.ConfigMap, &out.ConfigMap
-		*out = new(v1.ConfigMapKeySelector)
+		*out = new(corev1.ConfigMapKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Secret != nil {
 		in, out := &in.Secret, &out.Secret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.FieldRef != nil {
 		in, out := &in.FieldRef, &out.FieldRef
-		*out = new(v1.ObjectFieldSelector)
+		*out = new(corev1.ObjectFieldSelector)
 		**out = **in
 	}
 }
@@ -118,6 +126,59 @@ func (in *AddToVolumeConfiguration) DeepCopy() *AddToVolumeConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdditionalContainer) DeepCopyInto(out *AdditionalContainer) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.ContainerSettings.DeepCopyInto(&out.ContainerSettings)
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalContainer.
+func (in *AdditionalContainer) DeepCopy() *AdditionalContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(AdditionalContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingFromSpec) DeepCopyInto(out *AutoscalingFromSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingFromSpec.
+func (in *AutoscalingFromSpec) DeepCopy() *AutoscalingFromSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingFromSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuiltInfunction) DeepCopyInto(out *BuiltInfunction) {
 	*out = *in
@@ -138,6 +199,36 @@ func (in *BuiltInfunction) DeepCopy() *BuiltInfunction {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
+	*out = *in
+	if in.StatusCapabilities != nil {
+		in, out := &in.StatusCapabilities, &out.StatusCapabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HookCapabilities != nil {
+		in, out := &in.HookCapabilities, &out.HookCapabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatus.
+func (in *ComponentStatus) DeepCopy() *ComponentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Condition) DeepCopyInto(out *Condition) {
 	*out = *in
@@ -190,13 +281,165 @@ func (in *ConditionsFromHook) DeepCopy() *ConditionsFromHook {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerConfiguration) DeepCopyInto(out *ContainerConfiguration) {
+	*out = *in
+	in.ContainerSettings.DeepCopyInto(&out.ContainerSettings)
+	if in.FromSpec != nil {
+		in, out := &in.FromSpec, &out.FromSpec
+		*out = new(ContainerFromSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerConfiguration.
+func (in *ContainerConfiguration) DeepCopy() *ContainerConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerFromSpec) DeepCopyInto(out *ContainerFromSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerFromSpec.
+func (in *ContainerFromSpec) DeepCopy() *ContainerFromSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerFromSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in ContainerSelector) DeepCopyInto(out *ContainerSelector) {
+	{
+		in := &in
+		*out = make(ContainerSelector, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerSelector.
+func (in ContainerSelector) DeepCopy() ContainerSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerSelector)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerSettings) DeepCopyInto(out *ContainerSettings) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]corev1.ContainerPort, len(*in))
+		copy(*out, *in)
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(corev1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(corev1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(corev1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Lifecycle != nil {
+		in, out := &in.Lifecycle, &out.Lifecycle
+		*out = new(corev1.Lifecycle)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerSettings.
+func (in *ContainerSettings) DeepCopy() *ContainerSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentAutoscaling) DeepCopyInto(out *DeploymentAutoscaling) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetCPUUtilizationPercentage != nil {
+		in, out := &in.TargetCPUUtilizationPercentage, &out.TargetCPUUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetMemoryUtilizationPercentage != nil {
+		in, out := &in.TargetMemoryUtilizationPercentage, &out.TargetMemoryUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]v2.MetricSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FromSpec != nil {
+		in, out := &in.FromSpec, &out.FromSpec
+		*out = new(AutoscalingFromSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentAutoscaling.
+func (in *DeploymentAutoscaling) DeepCopy() *DeploymentAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeploymentFormFactor) DeepCopyInto(out *DeploymentFormFactor) {
 	*out = *in
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(DeploymentAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(DeploymentPodDisruptionBudget)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Service != nil {
 		in, out := &in.Service, &out.Service
 		*out = new(DeploymentService)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -210,9 +453,49 @@ func (in *DeploymentFormFactor) DeepCopy() *DeploymentFormFactor {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentPodDisruptionBudget) DeepCopyInto(out *DeploymentPodDisruptionBudget) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentPodDisruptionBudget.
+func (in *DeploymentPodDisruptionBudget) DeepCopy() *DeploymentPodDisruptionBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentPodDisruptionBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeploymentService) DeepCopyInto(out *DeploymentService) {
 	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]DeploymentServicePort, len(*in))
+		copy(*out, *in)
+	}
+	if in.Address != nil {
+		in, out := &in.Address, &out.Address
+		*out = new(DeploymentServiceAddress)
+		**out = **in
+	}
+	if in.Expose != nil {
+		in, out := &in.Expose, &out.Expose
+		*out = new(DeploymentServiceExpose)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentService.
@@ -225,6 +508,128 @@ func (in *DeploymentService) DeepCopy() *DeploymentService {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentServiceAddress) DeepCopyInto(out *DeploymentServiceAddress) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentServiceAddress.
+func (in *DeploymentServiceAddress) DeepCopy() *DeploymentServiceAddress {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentServiceAddress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentServiceExpose) DeepCopyInto(out *DeploymentServiceExpose) {
+	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(DeploymentServiceExposeTLS)
+		**out = **in
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(DeploymentServiceIngress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTPRoute != nil {
+		in, out := &in.HTTPRoute, &out.HTTPRoute
+		*out = new(DeploymentServiceHTTPRoute)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentServiceExpose.
+func (in *DeploymentServiceExpose) DeepCopy() *DeploymentServiceExpose {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentServiceExpose)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentServiceExposeTLS) DeepCopyInto(out *DeploymentServiceExposeTLS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentServiceExposeTLS.
+func (in *DeploymentServiceExposeTLS) DeepCopy() *DeploymentServiceExposeTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentServiceExposeTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentServiceHTTPRoute) DeepCopyInto(out *DeploymentServiceHTTPRoute) {
+	*out = *in
+	if in.ParentRefs != nil {
+		in, out := &in.ParentRefs, &out.ParentRefs
+		*out = make([]HTTPRouteParentReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentServiceHTTPRoute.
+func (in *DeploymentServiceHTTPRoute) DeepCopy() *DeploymentServiceHTTPRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentServiceHTTPRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentServiceIngress) DeepCopyInto(out *DeploymentServiceIngress) {
+	*out = *in
+	if in.ClassName != nil {
+		in, out := &in.ClassName, &out.ClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentServiceIngress.
+func (in *DeploymentServiceIngress) DeepCopy() *DeploymentServiceIngress {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentServiceIngress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentServicePort) DeepCopyInto(out *DeploymentServicePort) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentServicePort.
+func (in *DeploymentServicePort) DeepCopy() *DeploymentServicePort {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentServicePort)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Destination) DeepCopyInto(out *Destination) {
 	*out = *in
@@ -250,6 +655,21 @@ func (in *Destination) DeepCopy() *Destination {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FieldMapping) DeepCopyInto(out *FieldMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FieldMapping.
+func (in *FieldMapping) DeepCopy() *FieldMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(FieldMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FormFactor) DeepCopyInto(out *FormFactor) {
 	*out = *in
@@ -342,6 +762,11 @@ func (in *FromSpecToEnv) DeepCopyInto(out *FromSpecToEnv) {
 		*out = new(SpecToEnvValueFrom)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make(ContainerSelector, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FromSpecToEnv.
@@ -358,81 +783,226 @@ func (in *FromSpecToEnv) DeepCopy() *FromSpecToEnv {
 func (in *FromSpecToVolume) DeepCopyInto(out *FromSpecToVolume) {
 	*out = *in
 	in.MountFrom.DeepCopyInto(&out.MountFrom)
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make(ContainerSelector, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FromSpecToVolume.
+func (in *FromSpecToVolume) DeepCopy() *FromSpecToVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(FromSpecToVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalParameterConfiguration) DeepCopyInto(out *GlobalParameterConfiguration) {
+	*out = *in
+	if in.DefaultPrefix != nil {
+		in, out := &in.DefaultPrefix, &out.DefaultPrefix
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalParameterConfiguration.
+func (in *GlobalParameterConfiguration) DeepCopy() *GlobalParameterConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalParameterConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRouteParentReference) DeepCopyInto(out *HTTPRouteParentReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRouteParentReference.
+func (in *HTTPRouteParentReference) DeepCopy() *HTTPRouteParentReference {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRouteParentReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Hook) DeepCopyInto(out *Hook) {
+	*out = *in
+	in.Address.DeepCopyInto(&out.Address)
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(string)
+		**out = **in
+	}
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = make(HookCapabilities, len(*in))
+		copy(*out, *in)
+	}
+	if in.Transport != nil {
+		in, out := &in.Transport, &out.Transport
+		*out = new(HookTransport)
+		**out = **in
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(HookRetry)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CircuitBreaker != nil {
+		in, out := &in.CircuitBreaker, &out.CircuitBreaker
+		*out = new(HookCircuitBreaker)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Hook.
+func (in *Hook) DeepCopy() *Hook {
+	if in == nil {
+		return nil
+	}
+	out := new(Hook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in HookCapabilities) DeepCopyInto(out *HookCapabilities) {
+	{
+		in := &in
+		*out = make(HookCapabilities, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookCapabilities.
+func (in HookCapabilities) DeepCopy() HookCapabilities {
+	if in == nil {
+		return nil
+	}
+	out := new(HookCapabilities)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HookCircuitBreaker) DeepCopyInto(out *HookCircuitBreaker) {
+	*out = *in
+	if in.ResetTimeout != nil {
+		in, out := &in.ResetTimeout, &out.ResetTimeout
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookCircuitBreaker.
+func (in *HookCircuitBreaker) DeepCopy() *HookCircuitBreaker {
+	if in == nil {
+		return nil
+	}
+	out := new(HookCircuitBreaker)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HookRetry) DeepCopyInto(out *HookRetry) {
+	*out = *in
+	if in.Attempts != nil {
+		in, out := &in.Attempts, &out.Attempts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BackoffDelay != nil {
+		in, out := &in.BackoffDelay, &out.BackoffDelay
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxBackoffDelay != nil {
+		in, out := &in.MaxBackoffDelay, &out.MaxBackoffDelay
+		*out = new(string)
+		**out = **in
+	}
+	if in.Jitter != nil {
+		in, out := &in.Jitter, &out.Jitter
+		*out = new(bool)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FromSpecToVolume.
-func (in *FromSpecToVolume) DeepCopy() *FromSpecToVolume {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookRetry.
+func (in *HookRetry) DeepCopy() *HookRetry {
 	if in == nil {
 		return nil
 	}
-	out := new(FromSpecToVolume)
+	out := new(HookRetry)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GlobalParameterConfiguration) DeepCopyInto(out *GlobalParameterConfiguration) {
+func (in *ImageFromSpec) DeepCopyInto(out *ImageFromSpec) {
 	*out = *in
-	if in.DefaultPrefix != nil {
-		in, out := &in.DefaultPrefix, &out.DefaultPrefix
-		*out = new(string)
-		**out = **in
+	if in.AllowedRegistries != nil {
+		in, out := &in.AllowedRegistries, &out.AllowedRegistries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalParameterConfiguration.
-func (in *GlobalParameterConfiguration) DeepCopy() *GlobalParameterConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageFromSpec.
+func (in *ImageFromSpec) DeepCopy() *ImageFromSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GlobalParameterConfiguration)
+	out := new(ImageFromSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Hook) DeepCopyInto(out *Hook) {
+func (in *ImagePullSecret) DeepCopyInto(out *ImagePullSecret) {
 	*out = *in
-	in.Address.DeepCopyInto(&out.Address)
-	if in.Timeout != nil {
-		in, out := &in.Timeout, &out.Timeout
-		*out = new(string)
-		**out = **in
-	}
-	if in.Capabilities != nil {
-		in, out := &in.Capabilities, &out.Capabilities
-		*out = make(HookCapabilities, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Hook.
-func (in *Hook) DeepCopy() *Hook {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullSecret.
+func (in *ImagePullSecret) DeepCopy() *ImagePullSecret {
 	if in == nil {
 		return nil
 	}
-	out := new(Hook)
+	out := new(ImagePullSecret)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in HookCapabilities) DeepCopyInto(out *HookCapabilities) {
-	{
-		in := &in
-		*out = make(HookCapabilities, len(*in))
-		copy(*out, *in)
+func (in *KnativeServiceAutoscaling) DeepCopyInto(out *KnativeServiceAutoscaling) {
+	*out = *in
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(int)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookCapabilities.
-func (in HookCapabilities) DeepCopy() HookCapabilities {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KnativeServiceAutoscaling.
+func (in *KnativeServiceAutoscaling) DeepCopy() *KnativeServiceAutoscaling {
 	if in == nil {
 		return nil
 	}
-	out := new(HookCapabilities)
+	out := new(KnativeServiceAutoscaling)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -453,6 +1023,43 @@ func (in *KnativeServiceFormFactor) DeepCopyInto(out *KnativeServiceFormFactor)
 		*out = new(string)
 		**out = **in
 	}
+	if in.InitialScale != nil {
+		in, out := &in.InitialScale, &out.InitialScale
+		*out = new(int)
+		**out = **in
+	}
+	if in.ScaleDownDelay != nil {
+		in, out := &in.ScaleDownDelay, &out.ScaleDownDelay
+		*out = new(string)
+		**out = **in
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(KnativeServiceAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContainerConcurrency != nil {
+		in, out := &in.ContainerConcurrency, &out.ContainerConcurrency
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ResponseStartTimeoutSeconds != nil {
+		in, out := &in.ResponseStartTimeoutSeconds, &out.ResponseStartTimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Traffic != nil {
+		in, out := &in.Traffic, &out.Traffic
+		*out = make([]KnativeServiceTrafficTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KnativeServiceFormFactor.
@@ -465,17 +1072,42 @@ func (in *KnativeServiceFormFactor) DeepCopy() *KnativeServiceFormFactor {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KnativeServiceTrafficTarget) DeepCopyInto(out *KnativeServiceTrafficTarget) {
+	*out = *in
+	if in.LatestRevision != nil {
+		in, out := &in.LatestRevision, &out.LatestRevision
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Percent != nil {
+		in, out := &in.Percent, &out.Percent
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KnativeServiceTrafficTarget.
+func (in *KnativeServiceTrafficTarget) DeepCopy() *KnativeServiceTrafficTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(KnativeServiceTrafficTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MountFrom) DeepCopyInto(out *MountFrom) {
 	*out = *in
 	if in.ConfigMap != nil {
 		in, out := &in.ConfigMap, &out.ConfigMap
-		*out = new(v1.ConfigMapVolumeSource)
+		*out = new(corev1.ConfigMapVolumeSource)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Secret != nil {
 		in, out := &in.Secret, &out.Secret
-		*out = new(v1.SecretVolumeSource)
+		*out = new(corev1.SecretVolumeSource)
 		(*in).DeepCopyInto(*out)
 	}
 }
@@ -538,6 +1170,16 @@ func (in *Reference) DeepCopy() *Reference {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegistrationFromImage) DeepCopyInto(out *RegistrationFromImage) {
 	*out = *in
+	if in.PullSecrets != nil {
+		in, out := &in.PullSecrets, &out.PullSecrets
+		*out = make([]ImagePullSecret, len(*in))
+		copy(*out, *in)
+	}
+	if in.FromSpec != nil {
+		in, out := &in.FromSpec, &out.FromSpec
+		*out = new(ImageFromSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistrationFromImage.
@@ -550,6 +1192,137 @@ func (in *RegistrationFromImage) DeepCopy() *RegistrationFromImage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulingConfiguration) DeepCopyInto(out *SchedulingConfiguration) {
+	*out = *in
+	in.SchedulingSettings.DeepCopyInto(&out.SchedulingSettings)
+	if in.FromSpec != nil {
+		in, out := &in.FromSpec, &out.FromSpec
+		*out = new(SchedulingFromSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingConfiguration.
+func (in *SchedulingConfiguration) DeepCopy() *SchedulingConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulingConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulingFromSpec) DeepCopyInto(out *SchedulingFromSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingFromSpec.
+func (in *SchedulingFromSpec) DeepCopy() *SchedulingFromSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulingFromSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulingSettings) DeepCopyInto(out *SchedulingSettings) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingSettings.
+func (in *SchedulingSettings) DeepCopy() *SchedulingSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulingSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityConfiguration) DeepCopyInto(out *SecurityConfiguration) {
+	*out = *in
+	if in.Pod != nil {
+		in, out := &in.Pod, &out.Pod
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Container != nil {
+		in, out := &in.Container, &out.Container
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityConfiguration.
+func (in *SecurityConfiguration) DeepCopy() *SecurityConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountConfiguration) DeepCopyInto(out *ServiceAccountConfiguration) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]v1.PolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountConfiguration.
+func (in *ServiceAccountConfiguration) DeepCopy() *ServiceAccountConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpecToEnvDefaultValue) DeepCopyInto(out *SpecToEnvDefaultValue) {
 	*out = *in
@@ -560,12 +1333,12 @@ func (in *SpecToEnvDefaultValue) DeepCopyInto(out *SpecToEnvDefaultValue) {
 	}
 	if in.ConfigMap != nil {
 		in, out := &in.ConfigMap, &out.ConfigMap
-		*out = new(v1.ConfigMapKeySelector)
+		*out = new(corev1.ConfigMapKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Secret != nil {
 		in, out := &in.Secret, &out.Secret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 }
@@ -585,12 +1358,12 @@ func (in *SpecToEnvValueFrom) DeepCopyInto(out *SpecToEnvValueFrom) {
 	*out = *in
 	if in.ConfigMap != nil {
 		in, out := &in.ConfigMap, &out.ConfigMap
-		*out = new(v1.ConfigMapKeySelector)
+		*out = new(corev1.ConfigMapKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.Secret != nil {
 		in, out := &in.Secret, &out.Secret
-		*out = new(v1.SecretKeySelector)
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.BuiltInFunc != nil {
@@ -642,7 +1415,7 @@ func (in *Status) DeepCopy() *Status {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StatusAddElement) DeepCopyInto(out *StatusAddElement) {
 	*out = *in
-	out.ValueFrom = in.ValueFrom
+	in.ValueFrom.DeepCopyInto(&out.ValueFrom)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatusAddElement.
@@ -661,13 +1434,20 @@ func (in *StatusConfiguration) DeepCopyInto(out *StatusConfiguration) {
 	if in.AddElements != nil {
 		in, out := &in.AddElements, &out.AddElements
 		*out = make([]StatusAddElement, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.ConditionsFromHook != nil {
 		in, out := &in.ConditionsFromHook, &out.ConditionsFromHook
 		*out = make([]ConditionsFromHook, len(*in))
 		copy(*out, *in)
 	}
+	if in.Phase != nil {
+		in, out := &in.Phase, &out.Phase
+		*out = new(StatusPhase)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatusConfiguration.
@@ -680,9 +1460,29 @@ func (in *StatusConfiguration) DeepCopy() *StatusConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatusPhase) DeepCopyInto(out *StatusPhase) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatusPhase.
+func (in *StatusPhase) DeepCopy() *StatusPhase {
+	if in == nil {
+		return nil
+	}
+	out := new(StatusPhase)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StatusValueFrom) DeepCopyInto(out *StatusValueFrom) {
 	*out = *in
+	if in.Child != nil {
+		in, out := &in.Child, &out.Child
+		*out = new(StatusValueFromChild)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatusValueFrom.
@@ -695,6 +1495,68 @@ func (in *StatusValueFrom) DeepCopy() *StatusValueFrom {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatusValueFromChild) DeepCopyInto(out *StatusValueFromChild) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatusValueFromChild.
+func (in *StatusValueFromChild) DeepCopy() *StatusValueFromChild {
+	if in == nil {
+		return nil
+	}
+	out := new(StatusValueFromChild)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VersionConfiguration) DeepCopyInto(out *VersionConfiguration) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]VersionRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VersionConfiguration.
+func (in *VersionConfiguration) DeepCopy() *VersionConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(VersionConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VersionRule) DeepCopyInto(out *VersionRule) {
+	*out = *in
+	if in.ParameterConfiguration != nil {
+		in, out := &in.ParameterConfiguration, &out.ParameterConfiguration
+		*out = new(ParameterConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FieldMappings != nil {
+		in, out := &in.FieldMappings, &out.FieldMappings
+		*out = make([]FieldMapping, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VersionRule.
+func (in *VersionRule) DeepCopy() *VersionRule {
+	if in == nil {
+		return nil
+	}
+	out := new(VersionRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Workload) DeepCopyInto(out *Workload) {
 	*out = *in
@@ -703,7 +1565,41 @@ func (in *Workload) DeepCopyInto(out *Workload) {
 		*out = new(FormFactor)
 		(*in).DeepCopyInto(*out)
 	}
-	out.FromImage = in.FromImage
+	in.FromImage.DeepCopyInto(&out.FromImage)
+	if in.Container != nil {
+		in, out := &in.Container, &out.Container
+		*out = new(ContainerConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(ServiceAccountConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Scheduling != nil {
+		in, out := &in.Scheduling, &out.Scheduling
+		*out = new(SchedulingConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(SecurityConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]AdditionalContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]AdditionalContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ParameterConfiguration != nil {
 		in, out := &in.ParameterConfiguration, &out.ParameterConfiguration
 		*out = new(ParameterConfiguration)
@@ -714,6 +1610,11 @@ func (in *Workload) DeepCopyInto(out *Workload) {
 		*out = new(StatusConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.VersionConfiguration != nil {
+		in, out := &in.VersionConfiguration, &out.VersionConfiguration
+		*out = new(VersionConfiguration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Workload.
@@ -0,0 +1,179 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package hookpb
+
+import (
+	"fmt"
+	"math"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	hookv1 "github.com/triggermesh/scoby/pkg/apis/hook/v1"
+)
+
+// NewHookRequest converts a hook request into its protobuf representation.
+func NewHookRequest(r *hookv1.HookRequest) (*HookRequest, error) {
+	obj, err := structpb.NewStruct(r.Object.Object)
+	if err != nil {
+		return nil, fmt.Errorf("could not convert object: %w", err)
+	}
+
+	children, err := newChildren(r.Children)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HookRequest{
+		FormFactor: &FormFactorInfo{Name: r.FormFactor.Name},
+		Object:     obj,
+		Phase:      string(r.Phase),
+		Children:   children,
+	}, nil
+}
+
+// ToHookRequest converts a protobuf hook request into the hook API request.
+func ToHookRequest(r *HookRequest) *hookv1.HookRequest {
+	hreq := &hookv1.HookRequest{
+		FormFactor: hookv1.FormFactorInfo{Name: r.GetFormFactor().GetName()},
+		Phase:      hookv1.Phase(r.GetPhase()),
+		Children:   toChildren(r.GetChildren()),
+	}
+
+	if u := toUnstructured(r.GetObject()); u != nil {
+		hreq.Object = *u
+	}
+
+	return hreq
+}
+
+// NewHookResponse converts a hook response into its protobuf representation.
+func NewHookResponse(r *hookv1.HookResponse) (*HookResponse, error) {
+	hres := &HookResponse{}
+	if r == nil {
+		return hres, nil
+	}
+
+	if r.Object != nil {
+		obj, err := structpb.NewStruct(r.Object.Object)
+		if err != nil {
+			return nil, fmt.Errorf("could not convert object: %w", err)
+		}
+		hres.Object = obj
+	}
+
+	children, err := newChildren(r.Children)
+	if err != nil {
+		return nil, err
+	}
+	hres.Children = children
+
+	return hres, nil
+}
+
+// NewHookResponseError converts a hook error into its protobuf representation.
+func NewHookResponseError(e *hookv1.HookResponseError) *HookResponseError {
+	return &HookResponseError{
+		Message:   e.Error(),
+		Permanent: e.Permanent,
+		Continue:  e.Continue,
+	}
+}
+
+// ToHookResponse converts a protobuf hook response into the hook API
+// response, or error when informed.
+func ToHookResponse(r *HookResponse) (*hookv1.HookResponse, *hookv1.HookResponseError) {
+	if e := r.GetError(); e != nil {
+		return nil, &hookv1.HookResponseError{
+			Message:   e.GetMessage(),
+			Permanent: e.Permanent,
+			Continue:  e.Continue,
+		}
+	}
+
+	return &hookv1.HookResponse{
+		Object:   toUnstructured(r.GetObject()),
+		Children: toChildren(r.GetChildren()),
+	}, nil
+}
+
+func newChildren(children map[string]*unstructured.Unstructured) (map[string]*Child, error) {
+	if len(children) == 0 {
+		return nil, nil
+	}
+
+	pc := make(map[string]*Child, len(children))
+	for k, v := range children {
+		// nil children are sent without object, which
+		// means they are omitted.
+		if v == nil {
+			pc[k] = &Child{}
+			continue
+		}
+
+		obj, err := structpb.NewStruct(v.Object)
+		if err != nil {
+			return nil, fmt.Errorf("could not convert child %q: %w", k, err)
+		}
+		pc[k] = &Child{Object: obj}
+	}
+
+	return pc, nil
+}
+
+func toChildren(children map[string]*Child) map[string]*unstructured.Unstructured {
+	if len(children) == 0 {
+		return nil
+	}
+
+	uc := make(map[string]*unstructured.Unstructured, len(children))
+	for k, v := range children {
+		uc[k] = toUnstructured(v.GetObject())
+	}
+
+	return uc
+}
+
+func toUnstructured(s *structpb.Struct) *unstructured.Unstructured {
+	if s == nil {
+		return nil
+	}
+
+	return &unstructured.Unstructured{Object: toMap(s)}
+}
+
+// toMap is similar to the structpb AsMap function, but converts whole numbers
+// to int64 the same way that the unstructured JSON decoder does.
+func toMap(s *structpb.Struct) map[string]interface{} {
+	m := make(map[string]interface{}, len(s.GetFields()))
+	for k, v := range s.GetFields() {
+		m[k] = toInterface(v)
+	}
+	return m
+}
+
+func toInterface(v *structpb.Value) interface{} {
+	switch v := v.GetKind().(type) {
+	case *structpb.Value_NumberValue:
+		if f := v.NumberValue; f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+			return int64(f)
+		}
+		return v.NumberValue
+	case *structpb.Value_StringValue:
+		return v.StringValue
+	case *structpb.Value_BoolValue:
+		return v.BoolValue
+	case *structpb.Value_StructValue:
+		return toMap(v.StructValue)
+	case *structpb.Value_ListValue:
+		l := make([]interface{}, len(v.ListValue.GetValues()))
+		for i, lv := range v.ListValue.GetValues() {
+			l[i] = toInterface(lv)
+		}
+		return l
+	}
+
+	return nil
+}
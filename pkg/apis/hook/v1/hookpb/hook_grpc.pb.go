@@ -0,0 +1,109 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: pkg/apis/hook/v1/hookpb/hook.proto
+
+package hookpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Hook_Reconcile_FullMethodName = "/scoby.hook.v1.Hook/Reconcile"
+)
+
+// HookClient is the client API for Hook service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type HookClient interface {
+	Reconcile(ctx context.Context, in *HookRequest, opts ...grpc.CallOption) (*HookResponse, error)
+}
+
+type hookClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHookClient(cc grpc.ClientConnInterface) HookClient {
+	return &hookClient{cc}
+}
+
+func (c *hookClient) Reconcile(ctx context.Context, in *HookRequest, opts ...grpc.CallOption) (*HookResponse, error) {
+	out := new(HookResponse)
+	err := c.cc.Invoke(ctx, Hook_Reconcile_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HookServer is the server API for Hook service.
+// All implementations must embed UnimplementedHookServer
+// for forward compatibility
+type HookServer interface {
+	Reconcile(context.Context, *HookRequest) (*HookResponse, error)
+	mustEmbedUnimplementedHookServer()
+}
+
+// UnimplementedHookServer must be embedded to have forward compatible implementations.
+type UnimplementedHookServer struct {
+}
+
+func (UnimplementedHookServer) Reconcile(context.Context, *HookRequest) (*HookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reconcile not implemented")
+}
+func (UnimplementedHookServer) mustEmbedUnimplementedHookServer() {}
+
+// UnsafeHookServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HookServer will
+// result in compilation errors.
+type UnsafeHookServer interface {
+	mustEmbedUnimplementedHookServer()
+}
+
+func RegisterHookServer(s grpc.ServiceRegistrar, srv HookServer) {
+	s.RegisterService(&Hook_ServiceDesc, srv)
+}
+
+func _Hook_Reconcile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HookServer).Reconcile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Hook_Reconcile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HookServer).Reconcile(ctx, req.(*HookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Hook_ServiceDesc is the grpc.ServiceDesc for Hook service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Hook_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "scoby.hook.v1.Hook",
+	HandlerType: (*HookServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Reconcile",
+			Handler:    _Hook_Reconcile_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/apis/hook/v1/hookpb/hook.proto",
+}
@@ -4,6 +4,8 @@
 package v1alpha1
 
 import (
+	"strings"
+
 	commonv1alpha1 "github.com/triggermesh/scoby/pkg/apis/common/v1alpha1"
 )
 
@@ -15,6 +17,43 @@ func (r *CRDRegistration) GetHook() *commonv1alpha1.Hook {
 	return r.Spec.Hook
 }
 
+// GetCRDName returns the name of the registered CRD, either referenced
+// or generated.
+func (r *CRDRegistration) GetCRDName() string {
+	if r.Spec.Generate != nil {
+		return r.Spec.Generate.GetPlural() + "." + r.Spec.Generate.Group
+	}
+
+	return r.Spec.CRD
+}
+
+// GetVersion returns the version of the generated CRD.
+func (g *GeneratedCRD) GetVersion() string {
+	if g.Version == "" {
+		return "v1alpha1"
+	}
+
+	return g.Version
+}
+
+// GetPlural returns the plural name of the generated CRD.
+func (g *GeneratedCRD) GetPlural() string {
+	if g.Names.Plural == "" {
+		return strings.ToLower(g.Names.Kind) + "s"
+	}
+
+	return g.Names.Plural
+}
+
+// GetSingular returns the singular name of the generated CRD.
+func (g *GeneratedCRD) GetSingular() string {
+	if g.Names.Singular == "" {
+		return strings.ToLower(g.Names.Kind)
+	}
+
+	return g.Names.Singular
+}
+
 const (
 	CRDRegistrationConditionCRDExists       = "CRDExists"
 	CRDRegistrationConditionControllerReady = "ControllerReady"
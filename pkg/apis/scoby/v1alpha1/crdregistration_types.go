@@ -5,24 +5,87 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	commonv1alpha1 "github.com/triggermesh/scoby/pkg/apis/common/v1alpha1"
 )
 
 // CRDRegistrationSpec defines the desired state of a CRD Registration
+// +kubebuilder:validation:XValidation:rule="has(self.crd) != has(self.generate)",message="either crd or generate must be informed"
 type CRDRegistrationSpec struct {
 	// Name of the CRD to be used.
-	CRD string `json:"crd"`
+	// +optional
+	CRD string `json:"crd,omitempty"`
+
+	// Generate informs the CRD that Scoby creates and owns for
+	// the registration. Mutually exclusive with crd.
+	// +optional
+	Generate *GeneratedCRD `json:"generate,omitempty"`
 
 	// Workload is information on how to create the user workload.
 	Workload commonv1alpha1.Workload `json:"workload"`
 
 	Hook *commonv1alpha1.Hook `json:"hook,omitempty"`
+
+	// NamespaceSelector restricts the controller to instances at
+	// namespaces matching the selector. When not informed all
+	// namespaces are watched.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// GeneratedCRD is a compact definition of a CRD. The generated CRD
+// includes a status schema that Scoby is able to fill.
+type GeneratedCRD struct {
+	// Group of the CRD.
+	Group string `json:"group"`
+
+	// Version of the CRD, defaults to v1alpha1.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Names of the CRD.
+	Names GeneratedCRDNames `json:"names"`
+
+	// Spec is the OpenAPI v3 schema for the spec element of
+	// instances. When not informed any spec is accepted.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	Spec *runtime.RawExtension `json:"spec,omitempty"`
+}
+
+// GeneratedCRDNames are the names for the generated CRD.
+type GeneratedCRDNames struct {
+	// Kind of the CRD.
+	Kind string `json:"kind"`
+
+	// Plural name of the CRD, defaults to the lowercased kind
+	// followed by an s.
+	// +optional
+	Plural string `json:"plural,omitempty"`
+
+	// Singular name of the CRD, defaults to the lowercased kind.
+	// +optional
+	Singular string `json:"singular,omitempty"`
+
+	// ShortNames of the CRD.
+	// +optional
+	ShortNames []string `json:"shortNames,omitempty"`
+
+	// Categories the CRD belongs to.
+	// +optional
+	Categories []string `json:"categories,omitempty"`
 }
 
 // CRDRegistrationStatus defines the observed state of CRDRegistration
 type CRDRegistrationStatus struct {
 	commonv1alpha1.Status `json:",inline"`
+
+	// Component summarizes the controller for the registration
+	// and its instances.
+	// +optional
+	Component *commonv1alpha1.ComponentStatus `json:"component,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -32,6 +95,8 @@ type CRDRegistrationStatus struct {
 // CRDRegistration uses existing CRDs to provide generic controllers for them.
 // +kubebuilder:printcolumn:name="CRD",type="string",JSONPath=".spec.crd"
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Instances",type="integer",JSONPath=".status.component.instances"
+// +kubebuilder:printcolumn:name="Ready Instances",type="integer",JSONPath=".status.component.readyInstances"
 type CRDRegistration struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -10,7 +10,8 @@ package v1alpha1
 
 import (
 	commonv1alpha1 "github.com/triggermesh/scoby/pkg/apis/common/v1alpha1"
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -75,12 +76,22 @@ func (in *CRDRegistrationList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CRDRegistrationSpec) DeepCopyInto(out *CRDRegistrationSpec) {
 	*out = *in
+	if in.Generate != nil {
+		in, out := &in.Generate, &out.Generate
+		*out = new(GeneratedCRD)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Workload.DeepCopyInto(&out.Workload)
 	if in.Hook != nil {
 		in, out := &in.Hook, &out.Hook
 		*out = new(commonv1alpha1.Hook)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CRDRegistrationSpec.
@@ -97,6 +108,11 @@ func (in *CRDRegistrationSpec) DeepCopy() *CRDRegistrationSpec {
 func (in *CRDRegistrationStatus) DeepCopyInto(out *CRDRegistrationStatus) {
 	*out = *in
 	in.Status.DeepCopyInto(&out.Status)
+	if in.Component != nil {
+		in, out := &in.Component, &out.Component
+		*out = new(commonv1alpha1.ComponentStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CRDRegistrationStatus.
@@ -108,3 +124,49 @@ func (in *CRDRegistrationStatus) DeepCopy() *CRDRegistrationStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeneratedCRD) DeepCopyInto(out *GeneratedCRD) {
+	*out = *in
+	in.Names.DeepCopyInto(&out.Names)
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeneratedCRD.
+func (in *GeneratedCRD) DeepCopy() *GeneratedCRD {
+	if in == nil {
+		return nil
+	}
+	out := new(GeneratedCRD)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeneratedCRDNames) DeepCopyInto(out *GeneratedCRDNames) {
+	*out = *in
+	if in.ShortNames != nil {
+		in, out := &in.ShortNames, &out.ShortNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Categories != nil {
+		in, out := &in.Categories, &out.Categories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeneratedCRDNames.
+func (in *GeneratedCRDNames) DeepCopy() *GeneratedCRDNames {
+	if in == nil {
+		return nil
+	}
+	out := new(GeneratedCRDNames)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -6,13 +6,17 @@ package builder
 import (
 	"context"
 	"fmt"
+	"io"
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	commonv1alpha1 "github.com/triggermesh/scoby/pkg/apis/common/v1alpha1"
+	hookv1 "github.com/triggermesh/scoby/pkg/apis/hook/v1"
 	"github.com/triggermesh/scoby/pkg/component/reconciler"
 	"github.com/triggermesh/scoby/pkg/component/reconciler/base"
 	basecrd "github.com/triggermesh/scoby/pkg/component/reconciler/base/crd"
@@ -23,24 +27,91 @@ import (
 	"github.com/triggermesh/scoby/pkg/component/reconciler/formfactor/knservice"
 	"github.com/triggermesh/scoby/pkg/component/reconciler/hook"
 	"github.com/triggermesh/scoby/pkg/utils/configmap"
+	"github.com/triggermesh/scoby/pkg/utils/events"
 	"github.com/triggermesh/scoby/pkg/utils/resolver"
 )
 
 type Builder interface {
-	StartNewReconciler(ctx context.Context, crd *apiextensionsv1.CustomResourceDefinition, reg commonv1alpha1.Registration) (chan error, error)
+	StartNewReconciler(ctx context.Context, crd *apiextensionsv1.CustomResourceDefinition, reg commonv1alpha1.Registration, namespaces []string) (*Component, error)
+}
+
+// Component is a running controller for a registration.
+type Component struct {
+	// Stopped receives the controller outcome once it stops. It is buffered
+	// so that the controller can stop when nobody is waiting for it.
+	Stopped chan error
+
+	info      commonv1alpha1.ComponentStatus
+	inventory *base.Inventory
+}
+
+// CRDVersion returns the CRD version served by the controller.
+func (c *Component) CRDVersion() string {
+	return c.info.CRDVersion
+}
+
+// Namespaces returns the namespaces the controller is restricted to.
+func (c *Component) Namespaces() []string {
+	return c.info.Namespaces
+}
+
+// SelectCRDVersion returns the CRD version to be served for the
+// registration.
+func SelectCRDVersion(crd *apiextensionsv1.CustomResourceDefinition, reg commonv1alpha1.Registration) *apiextensionsv1.CustomResourceDefinitionVersion {
+	var selection commonv1alpha1.VersionSelection
+	if vc := reg.GetWorkload().VersionConfiguration; vc != nil {
+		selection = vc.Selection
+	}
+
+	return basecrd.CRDSelectedVersion(crd, selection)
+}
+
+// Status returns the controller summary, including the instance counters
+// at the moment of the call.
+func (c *Component) Status() *commonv1alpha1.ComponentStatus {
+	cs := c.info.DeepCopy()
+	cs.Instances, cs.ReadyInstances, cs.LastRenderError = c.inventory.Summary()
+
+	return cs
 }
 
 type builder struct {
 	mgr   manager.Manager
 	reslv resolver.Resolver
 	cmr   configmap.Reader
+	hooks *hook.Registry
+
+	scobyReader    client.Reader
+	scobyNamespace string
+}
+
+// BuilderOption sets optional parameters for the builder.
+type BuilderOption func(*builder)
+
+// WithHookRegistry sets the registry of in-process hooks, which are used
+// for matching registrations instead of the hook at the registration spec.
+func WithHookRegistry(r *hook.Registry) BuilderOption {
+	return func(b *builder) {
+		b.hooks = r
+	}
 }
 
-func (b *builder) StartNewReconciler(ctx context.Context, crd *apiextensionsv1.CustomResourceDefinition, reg commonv1alpha1.Registration) (chan error, error) {
+// WithScobyNamespaceReader sets the reader for objects at the Scoby
+// namespace, used to copy pull secrets to the instances namespace.
+func WithScobyNamespaceReader(reader client.Reader, namespace string) BuilderOption {
+	return func(b *builder) {
+		b.scobyReader = reader
+		b.scobyNamespace = namespace
+	}
+}
+
+func (b *builder) StartNewReconciler(ctx context.Context, crd *apiextensionsv1.CustomResourceDefinition, reg commonv1alpha1.Registration, namespaces []string) (*Component, error) {
 	log := b.mgr.GetLogger()
 	log.V(1).Info("Starting new reconciler for registration", "registration", reg.GetName())
 
-	crdv := basecrd.CRDPrioritizedVersion(crd)
+	wkl := reg.GetWorkload()
+
+	crdv := SelectCRDVersion(crd, reg)
 	if crdv == nil {
 		return nil, fmt.Errorf("no available CRD version for %s at %s", crd.GetName(), reg.GetName())
 	}
@@ -51,68 +122,207 @@ func (b *builder) StartNewReconciler(ctx context.Context, crd *apiextensionsv1.C
 		Kind:    crd.Spec.Names.Kind,
 	}
 
-	wkl := reg.GetWorkload()
+	// Events are shared by all reconcilers of the registration, repeated
+	// events are dropped to avoid flooding when reconciliation keeps
+	// failing the same way.
+	recorder := events.NewRateLimitedRecorder(b.mgr.GetEventRecorderFor(reconciler.ManagedBy), events.DefaultInterval)
+
+	var ffopts []reconciler.FormFactorOption
+	var nsc cache.Cache
+	var nscClient client.Client
+	if len(namespaces) != 0 {
+		// Instances and their children are watched and read using a cache
+		// restricted to the namespaces, which is stopped along with the
+		// controller.
+		var err error
+		nsc, err = cache.New(b.mgr.GetConfig(), cache.Options{
+			Scheme:     b.mgr.GetScheme(),
+			Mapper:     b.mgr.GetRESTMapper(),
+			Namespaces: namespaces,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not create namespaced cache for %s at %s: %w", crd.GetName(), reg.GetName(), err)
+		}
+
+		nscClient, err = client.New(b.mgr.GetConfig(), client.Options{
+			Scheme: b.mgr.GetScheme(),
+			Mapper: b.mgr.GetRESTMapper(),
+			Cache:  &client.CacheOptions{Reader: nsc},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not create namespaced client for %s at %s: %w", crd.GetName(), reg.GetName(), err)
+		}
+
+		ffopts = append(ffopts, reconciler.WithCache(nsc, nscClient))
+	}
 
 	var ffr reconciler.FormFactorReconciler
 	switch {
 	case wkl.FormFactor.KnativeService != nil:
-		ffr = knservice.New(reg.GetName(), wkl, b.mgr)
+		ffr = knservice.New(reg.GetName(), wkl, b.mgr, recorder, ffopts...)
 
 	default:
 		// Defaults to deployment
-		ffr = deployment.New(reg.GetName(), wkl, b.mgr)
+		ffr = deployment.New(reg.GetName(), wkl, b.mgr, recorder, ffopts...)
 	}
 
-	// The status factory is created using the form factor's conditions
+	// The status factory is created using the form factor's conditions,
+	// rendering status is informed for every object.
 	happy, all := ffr.GetStatusConditions()
+	all = append(all, reconciler.ConditionTypeRenderReady)
 
 	var hr reconciler.HookReconciler
-	if h := reg.GetHook(); h != nil {
+	// Hook connections created by the builder are released when the
+	// controller stops. In-process hooks are owned by library users.
+	var hrCloser io.Closer
+
+	// Add conditions informed from a hook
+	var cfh []commonv1alpha1.ConditionsFromHook
+	if wkl.StatusConfiguration != nil {
+		cfh = wkl.StatusConfiguration.ConditionsFromHook
+
+		if wkl.StatusConfiguration.HappyCondition != "" {
+			happy = wkl.StatusConfiguration.HappyCondition
+		}
+	}
+
+	if err := validateHappyCondition(happy, all, cfh); err != nil {
+		return nil, fmt.Errorf("invalid status configuration for %s at %s: %w", crd.GetName(), reg.GetName(), err)
+	}
+
+	// In-process hooks take precedence over hooks declared at the registration.
+	if ih, ok := b.hooks.Lookup(reg.GetName(), *gvk); ok {
+		log.Info("Configuring in-process hook", "registration", reg.GetName())
+		hr = ih
+
+	} else if h := reg.GetHook(); h != nil {
 		url := reg.GetStatusAnnotation(commonv1alpha1.CRDRegistrationAnnotationHookURL)
 		if url == nil {
 			return nil, fmt.Errorf("%s registration does not contain the %q status annotation",
 				reg.GetName(), commonv1alpha1.CRDRegistrationAnnotationHookURL)
 		}
 
-		// Add conditions informed from a hook
-		var cfh []commonv1alpha1.ConditionsFromHook
-		if wkl.StatusConfiguration != nil {
-			for _, c := range wkl.StatusConfiguration.ConditionsFromHook {
-				all = append(all, c.Type)
-			}
-			cfh = wkl.StatusConfiguration.ConditionsFromHook
+		log.Info("Configuring hook", "url", *url)
+		hr = hook.New(h, *url, cfh, ffr.GetInfo(), recorder, log)
+		hrCloser, _ = hr.(io.Closer)
+	}
+
+	severities := map[string]commonv1alpha1.ConditionSeverity{}
+	if hr != nil {
+		for _, c := range cfh {
+			all = append(all, c.Type)
+			severities[c.Type] = c.Severity
 		}
 
-		log.Info("Configuring hook", "url", *url)
-		hr = hook.New(h, *url, cfh, ffr.GetInfo(), log)
+		// Hooks inform their status through a condition.
+		all = append(all, reconciler.ConditionTypeHookReady)
 	}
 
-	renderer, err := baserenderer.NewRenderer(wkl, b.reslv, b.cmr)
+	renderer, err := baserenderer.NewRenderer(wkl, b.reslv, b.cmr, baserenderer.WithCRDVersion(crdv.Name))
 	if err != nil {
 		return nil, fmt.Errorf("could not create renderer for %s at %s: %w", crd.GetName(), reg.GetName(), err)
 	}
 
-	smf := basestatus.NewStatusManagerFactory(crdv, happy, all, log)
+	smfopts := []basestatus.StatusManagerFactoryOption{
+		basestatus.WithConditionSeverities(severities),
+	}
+	if sc := wkl.StatusConfiguration; sc != nil {
+		smfopts = append(smfopts,
+			basestatus.WithAddressURLPath(sc.AddressURLPath),
+			basestatus.WithPublicURLPath(sc.PublicURLPath),
+			basestatus.WithPhase(sc.Phase))
+	}
+
+	smf := basestatus.NewStatusManagerFactory(crdv, happy, all, log, smfopts...)
 
 	om := baseobject.NewManager(gvk, renderer, smf)
 
-	c, err := base.NewController(om, reg, ffr, hr, b.mgr, log)
+	var copts []base.ControllerOption
+	if b.scobyReader != nil {
+		copts = append(copts, base.WithScobyNamespaceReader(b.scobyReader, b.scobyNamespace))
+	}
+	if len(namespaces) != 0 {
+		go func() {
+			if err := nsc.Start(ctx); err != nil {
+				log.Error(err, "namespaced cache stopped with errors", "registration", reg.GetName())
+			}
+		}()
+
+		copts = append(copts, base.WithNamespaces(nsc, nscClient, namespaces))
+	}
+
+	inventory := base.NewInventory()
+	c, err := base.NewController(om, reg, ffr, hr, b.mgr, recorder, inventory, log, copts...)
 	if err != nil {
 		return nil, fmt.Errorf("could not create controller for %s at %s: %w", crd.GetName(), reg.GetName(), err)
 	}
 
-	stCh := make(chan error)
+	hookCaps := []string{}
+	if hr != nil {
+		if hr.IsPreReconciler() {
+			hookCaps = append(hookCaps, string(hookv1.PhasePreReconcile))
+		}
+		if hr.IsFinalizer() {
+			hookCaps = append(hookCaps, string(hookv1.PhaseFinalize))
+		}
+	}
+
+	component := &Component{
+		Stopped: make(chan error, 1),
+		info: commonv1alpha1.ComponentStatus{
+			CRDVersion:         crdv.Name,
+			FormFactor:         ffr.GetInfo().Name,
+			StatusCapabilities: basecrd.CRDStatusFlag(crdv).Capabilities(),
+			HookCapabilities:   hookCaps,
+			Namespaces:         namespaces,
+		},
+		inventory: inventory,
+	}
+
 	go func() {
-		stCh <- c.Start(ctx)
+		err := c.Start(ctx)
+
+		// Release hook connections once the controller is stopped.
+		if hrCloser != nil {
+			if err := hrCloser.Close(); err != nil {
+				log.Error(err, "could not close hook connections")
+			}
+		}
+
+		component.Stopped <- err
 	}()
 
-	return stCh, nil
+	return component, nil
 }
 
-func NewBuilder(mgr manager.Manager, reslv resolver.Resolver, cmr configmap.Reader) Builder {
-	return &builder{
+func NewBuilder(mgr manager.Manager, reslv resolver.Resolver, cmr configmap.Reader, opts ...BuilderOption) Builder {
+	b := &builder{
 		mgr:   mgr,
 		reslv: reslv,
 		cmr:   cmr,
 	}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b
+}
+
+// validateHappyCondition makes sure that the happy condition, which aggregates
+// all other conditions, is not one of the conditions informed by the form
+// factor, the renderer or the hook.
+func validateHappyCondition(happy string, conditions []string, cfh []commonv1alpha1.ConditionsFromHook) error {
+	informed := append([]string{reconciler.ConditionTypeHookReady}, conditions...)
+	for _, c := range cfh {
+		informed = append(informed, c.Type)
+	}
+
+	for _, c := range informed {
+		if c == happy {
+			return fmt.Errorf("happy condition %q is already informed by Scoby or the hook", happy)
+		}
+	}
+
+	return nil
 }
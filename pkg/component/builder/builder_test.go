@@ -0,0 +1,42 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	commonv1alpha1 "github.com/triggermesh/scoby/pkg/apis/common/v1alpha1"
+	"github.com/triggermesh/scoby/pkg/component/reconciler"
+	"github.com/triggermesh/scoby/pkg/component/reconciler/formfactor/deployment"
+)
+
+func TestValidateHappyCondition(t *testing.T) {
+	conditions := []string{deployment.ConditionTypeDeploymentReady, reconciler.ConditionTypeRenderReady}
+	cfh := []commonv1alpha1.ConditionsFromHook{{Type: "Synced"}}
+
+	testCases := map[string]struct {
+		happy       string
+		expectError bool
+	}{
+		"default":                     {happy: reconciler.ConditionTypeReady},
+		"custom":                      {happy: "Serving"},
+		"form factor condition":       {happy: deployment.ConditionTypeDeploymentReady, expectError: true},
+		"render condition":            {happy: reconciler.ConditionTypeRenderReady, expectError: true},
+		"hook condition":              {happy: reconciler.ConditionTypeHookReady, expectError: true},
+		"condition informed by hooks": {happy: "Synced", expectError: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateHappyCondition(tc.happy, conditions, cfh)
+			if tc.expectError {
+				assert.ErrorContains(t, err, "is already informed")
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
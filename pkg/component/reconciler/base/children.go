@@ -0,0 +1,317 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package base
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/triggermesh/scoby/pkg/component/reconciler"
+	"github.com/triggermesh/scoby/pkg/utils/resources"
+	"github.com/triggermesh/scoby/pkg/utils/semantic"
+)
+
+// childrenReconciler manages children objects that are not part of the
+// form factor, usually added by hooks.
+//
+// Children are labeled with the registration name, the instance name and
+// the key at the children map, which is used as an inventory to find and
+// delete children that are no longer desired. The kinds of the children
+// are kept at the instance status annotations so that stale children are
+// found after the controller restarts.
+type childrenReconciler struct {
+	registration string
+	client       client.Client
+	recorder     record.EventRecorder
+	log          logr.Logger
+
+	// kinds of the children created for the registration instances since
+	// the process started, used along with the kinds persisted at each
+	// instance to look up children that are no longer desired. Needed
+	// for CRDs that do not support status annotations.
+	kinds map[schema.GroupVersionKind]struct{}
+	m     sync.Mutex
+}
+
+func newChildrenReconciler(registration string, client client.Client, recorder record.EventRecorder, log logr.Logger) *childrenReconciler {
+	return &childrenReconciler{
+		registration: registration,
+		client:       client,
+		recorder:     recorder,
+		log:          log,
+		kinds:        make(map[schema.GroupVersionKind]struct{}),
+	}
+}
+
+// Reconcile creates or updates the children objects and deletes any
+// child that was previously created for the object but is no longer
+// informed. Nil children are considered omitted.
+func (cr *childrenReconciler) Reconcile(ctx context.Context, obj reconciler.Object, children map[string]*unstructured.Unstructured) error {
+	keys := make([]string, 0, len(children))
+	for k := range children {
+		if children[k] != nil {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	desired := make(map[string]*unstructured.Unstructured, len(keys))
+	for _, k := range keys {
+		child, err := cr.desiredChild(obj, k, children[k])
+		if err != nil {
+			return err
+		}
+
+		cr.addKind(child.GroupVersionKind())
+
+		if err := cr.reconcileChild(ctx, obj, child); err != nil {
+			return err
+		}
+
+		desired[k] = child
+	}
+
+	if err := cr.deleteStale(ctx, obj, desired); err != nil {
+		return err
+	}
+
+	cr.persistKinds(obj, desired)
+	return nil
+}
+
+// persistKinds writes the kinds of the desired children at the instance
+// status annotations. Stale children have been deleted at this point.
+func (cr *childrenReconciler) persistKinds(obj reconciler.Object, desired map[string]*unstructured.Unstructured) {
+	set := make(map[string]struct{}, len(desired))
+	for _, d := range desired {
+		gvk := d.GroupVersionKind()
+		set[gvk.GroupVersion().String()+"/"+gvk.Kind] = struct{}{}
+	}
+
+	kinds := make([]string, 0, len(set))
+	for k := range set {
+		kinds = append(kinds, k)
+	}
+	sort.Strings(kinds)
+	value := strings.Join(kinds, ",")
+
+	u := obj.AsKubeObject().(*unstructured.Unstructured)
+	annotations, _, _ := unstructured.NestedStringMap(u.Object, "status", "annotations")
+	if annotations[reconciler.StatusAnnotationChildrenKinds] == value {
+		return
+	}
+
+	if err := obj.GetStatusManager().SetAnnotation(reconciler.StatusAnnotationChildrenKinds, value); err != nil {
+		cr.log.Error(err, "could not set children kinds status annotation", "object", obj)
+	}
+}
+
+// persistedKinds returns the kinds of children written at the instance
+// status annotations.
+func (cr *childrenReconciler) persistedKinds(obj reconciler.Object) []schema.GroupVersionKind {
+	u := obj.AsKubeObject().(*unstructured.Unstructured)
+	annotations, _, _ := unstructured.NestedStringMap(u.Object, "status", "annotations")
+	value := annotations[reconciler.StatusAnnotationChildrenKinds]
+	if value == "" {
+		return nil
+	}
+
+	kinds := []schema.GroupVersionKind{}
+	for _, k := range strings.Split(value, ",") {
+		i := strings.LastIndex(k, "/")
+		if i <= 0 {
+			cr.log.Info("ignoring malformed children kind at status annotation", "object", obj, "kind", k)
+			continue
+		}
+
+		gv, err := schema.ParseGroupVersion(k[:i])
+		if err != nil {
+			cr.log.Info("ignoring malformed children kind at status annotation", "object", obj, "kind", k)
+			continue
+		}
+		kinds = append(kinds, gv.WithKind(k[i+1:]))
+	}
+
+	return kinds
+}
+
+func (cr *childrenReconciler) desiredChild(obj reconciler.Object, key string, child *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	if errs := validation.IsValidLabelValue(key); len(errs) != 0 {
+		return nil, fmt.Errorf("child key %q is not valid: %s", key, strings.Join(errs, ", "))
+	}
+
+	if child.GetName() == "" || child.GetKind() == "" || child.GetAPIVersion() == "" {
+		return nil, fmt.Errorf("child %q must inform apiVersion, kind and name", key)
+	}
+
+	desired := child.DeepCopy()
+
+	switch desired.GetNamespace() {
+	case "":
+		desired.SetNamespace(obj.GetNamespace())
+	case obj.GetNamespace():
+	default:
+		return nil, fmt.Errorf("child %q namespace %q does not match the object's namespace", key, desired.GetNamespace())
+	}
+
+	labels := desired.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string, 5)
+	}
+	labels[resources.AppNameLabel] = cr.registration
+	labels[resources.AppInstanceLabel] = obj.GetName()
+	labels[resources.AppPartOfLabel] = reconciler.PartOf
+	labels[resources.AppManagedByLabel] = reconciler.ManagedBy
+	labels[reconciler.ChildLabel] = key
+	desired.SetLabels(labels)
+
+	if err := controllerutil.SetControllerReference(obj.AsKubeObject(), desired, cr.client.Scheme()); err != nil {
+		return nil, fmt.Errorf("could not set owner for child %q: %w", key, err)
+	}
+
+	return desired, nil
+}
+
+func (cr *childrenReconciler) reconcileChild(ctx context.Context, obj reconciler.Object, desired *unstructured.Unstructured) error {
+	cr.log.V(1).Info("reconciling child", "object", obj, "child", desired.GetLabels()[reconciler.ChildLabel])
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(desired.GroupVersionKind())
+
+	err := cr.client.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	switch {
+	case err == nil:
+		if !metav1.IsControlledBy(existing, obj) {
+			return fmt.Errorf("child %s %s already exists and is not controlled by %s",
+				desired.GetKind(), client.ObjectKeyFromObject(desired), obj.GetName())
+		}
+
+		if semantic.Semantic.DeepEqual(desired, existing) {
+			return nil
+		}
+
+		cr.log.Info("existing child does not match the expected", "object", desired)
+		cr.log.V(5).Info("mismatched child", "desired", *desired, "existing", *existing)
+
+		// resourceVersion must be returned to the API server unmodified for
+		// optimistic concurrency, as per Kubernetes API conventions
+		desired.SetResourceVersion(existing.GetResourceVersion())
+
+		if err = cr.client.Update(ctx, desired); err != nil {
+			cr.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeWarning, reconciler.EventReasonChildFailed,
+				"Failed to update %s %s: %v", desired.GetKind(), desired.GetName(), err)
+			return fmt.Errorf("could not update child %s object: %w", desired.GetKind(), err)
+		}
+		cr.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeNormal, reconciler.EventReasonChildUpdated,
+			"Updated %s %s", desired.GetKind(), desired.GetName())
+
+	case apierrs.IsNotFound(err):
+		cr.log.Info("creating child", "object", desired)
+		if err = cr.client.Create(ctx, desired); err != nil {
+			cr.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeWarning, reconciler.EventReasonChildFailed,
+				"Failed to create %s %s: %v", desired.GetKind(), desired.GetName(), err)
+			return fmt.Errorf("could not create child %s object: %w", desired.GetKind(), err)
+		}
+		cr.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeNormal, reconciler.EventReasonChildCreated,
+			"Created %s %s", desired.GetKind(), desired.GetName())
+
+	default:
+		return fmt.Errorf("could not retrieve controlled child %s %s: %w",
+			desired.GetKind(), client.ObjectKeyFromObject(desired), err)
+	}
+
+	return nil
+}
+
+// deleteStale looks for children of the known and persisted kinds that were
+// created for the object and deletes those that are not desired anymore.
+func (cr *childrenReconciler) deleteStale(ctx context.Context, obj reconciler.Object, desired map[string]*unstructured.Unstructured) error {
+	kinds := make(map[schema.GroupVersionKind]struct{})
+	for _, gvk := range cr.getKinds() {
+		kinds[gvk] = struct{}{}
+	}
+	for _, gvk := range cr.persistedKinds(obj) {
+		kinds[gvk] = struct{}{}
+	}
+
+	for gvk := range kinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+
+		if err := cr.client.List(ctx, list,
+			client.InNamespace(obj.GetNamespace()),
+			client.MatchingLabels{
+				resources.AppNameLabel:     cr.registration,
+				resources.AppInstanceLabel: obj.GetName(),
+			},
+			client.HasLabels{reconciler.ChildLabel},
+		); err != nil {
+			// Kinds that are no longer served cannot have children.
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			return fmt.Errorf("could not list children %s: %w", gvk.Kind, err)
+		}
+
+		for i := range list.Items {
+			item := &list.Items[i]
+			if !metav1.IsControlledBy(item, obj) {
+				continue
+			}
+
+			if d, ok := desired[item.GetLabels()[reconciler.ChildLabel]]; ok &&
+				d.GroupVersionKind() == item.GroupVersionKind() &&
+				d.GetName() == item.GetName() {
+				continue
+			}
+
+			cr.log.Info("deleting child no longer desired", "object", obj, "child", client.ObjectKeyFromObject(item), "kind", gvk.Kind)
+			if err := cr.client.Delete(ctx, item); err != nil && !apierrs.IsNotFound(err) {
+				cr.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeWarning, reconciler.EventReasonChildFailed,
+					"Failed to delete %s %s: %v", gvk.Kind, item.GetName(), err)
+				return fmt.Errorf("could not delete child %s %s: %w", gvk.Kind, client.ObjectKeyFromObject(item), err)
+			}
+			cr.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeNormal, reconciler.EventReasonChildDeleted,
+				"Deleted %s %s", gvk.Kind, item.GetName())
+		}
+	}
+
+	return nil
+}
+
+func (cr *childrenReconciler) addKind(gvk schema.GroupVersionKind) {
+	cr.m.Lock()
+	defer cr.m.Unlock()
+
+	cr.kinds[gvk] = struct{}{}
+}
+
+func (cr *childrenReconciler) getKinds() []schema.GroupVersionKind {
+	cr.m.Lock()
+	defer cr.m.Unlock()
+
+	kinds := make([]schema.GroupVersionKind, 0, len(cr.kinds))
+	for k := range cr.kinds {
+		kinds = append(kinds, k)
+	}
+
+	return kinds
+}
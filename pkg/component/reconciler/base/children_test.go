@@ -0,0 +1,217 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package base
+
+import (
+	"context"
+	"testing"
+
+	tlogr "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/triggermesh/scoby/pkg/component/reconciler"
+	baseobject "github.com/triggermesh/scoby/pkg/component/reconciler/base/object"
+	basestatus "github.com/triggermesh/scoby/pkg/component/reconciler/base/status"
+)
+
+const (
+	tNamespace    = "test-ns"
+	tName         = "test-name"
+	tRegistration = "kuards"
+)
+
+// tCRDVersion contains a status that supports annotations and conditions.
+var tCRDVersion = &apiextensionsv1.CustomResourceDefinitionVersion{
+	Subresources: &apiextensionsv1.CustomResourceSubresources{
+		Status: &apiextensionsv1.CustomResourceSubresourceStatus{},
+	},
+	Schema: &apiextensionsv1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+			Properties: map[string]apiextensionsv1.JSONSchemaProps{
+				"status": {
+					Type: "object",
+					Properties: map[string]apiextensionsv1.JSONSchemaProps{
+						"annotations": {
+							Type: "object",
+							AdditionalProperties: &apiextensionsv1.JSONSchemaPropsOrBool{
+								Schema: &apiextensionsv1.JSONSchemaProps{Type: "string"},
+							},
+						},
+						"conditions": {
+							Type: "array",
+							Items: &apiextensionsv1.JSONSchemaPropsOrArray{
+								Schema: &apiextensionsv1.JSONSchemaProps{
+									Type: "object",
+									Properties: map[string]apiextensionsv1.JSONSchemaProps{
+										"type":               {Type: "string"},
+										"status":             {Type: "string"},
+										"reason":             {Type: "string"},
+										"message":            {Type: "string"},
+										"lastTransitionTime": {Type: "string"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+func newTestObject(t *testing.T) reconciler.Object {
+	gvk := &schema.GroupVersionKind{Group: "extensions.triggermesh.io", Version: "v1", Kind: "Kuard"}
+	smf := basestatus.NewStatusManagerFactory(tCRDVersion, reconciler.ConditionTypeReady,
+		[]string{reconciler.ConditionTypeRenderReady, reconciler.ConditionTypeHookReady}, tlogr.NewTestLogger(t))
+	obj := baseobject.NewManager(gvk, nil, smf).NewObject()
+	obj.SetNamespace(tNamespace)
+	obj.SetName(tName)
+	obj.SetUID("test-uid")
+	obj.GetStatusManager().SanitizeConditions()
+
+	return obj
+}
+
+func newTestConfigMap(name string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("v1")
+	u.SetKind("ConfigMap")
+	u.SetName(name)
+	_ = unstructured.SetNestedStringMap(u.Object, map[string]string{"key": "value"}, "data")
+
+	return u
+}
+
+func TestChildrenReconcile(t *testing.T) {
+	ctx := context.Background()
+	obj := newTestObject(t)
+
+	c := fake.NewClientBuilder().Build()
+	recorder := record.NewFakeRecorder(10)
+	cr := newChildrenReconciler(tRegistration, c, recorder, tlogr.NewTestLogger(t))
+
+	// Create children, nil children are omitted.
+	err := cr.Reconcile(ctx, obj, map[string]*unstructured.Unstructured{
+		"config":  newTestConfigMap("config-a"),
+		"other":   newTestConfigMap("config-b"),
+		"omitted": nil,
+	})
+	require.NoError(t, err)
+
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: tNamespace, Name: "config-a"}, cm))
+	assert.Equal(t, "config", cm.Labels[reconciler.ChildLabel])
+	assert.Equal(t, tRegistration, cm.Labels["app.kubernetes.io/name"])
+	assert.Equal(t, tName, cm.Labels["app.kubernetes.io/instance"])
+	assert.True(t, metav1.IsControlledBy(cm, obj))
+	assert.Equal(t, "Normal ChildCreated Created ConfigMap config-a", <-recorder.Events)
+	assert.Equal(t, "Normal ChildCreated Created ConfigMap config-b", <-recorder.Events)
+
+	// Update one child and stop informing the other one.
+	updated := newTestConfigMap("config-a")
+	_ = unstructured.SetNestedStringMap(updated.Object, map[string]string{"key": "updated"}, "data")
+
+	err = cr.Reconcile(ctx, obj, map[string]*unstructured.Unstructured{
+		"config": updated,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: tNamespace, Name: "config-a"}, cm))
+	assert.Equal(t, "updated", cm.Data["key"])
+
+	err = c.Get(ctx, client.ObjectKey{Namespace: tNamespace, Name: "config-b"}, cm)
+	assert.True(t, apierrs.IsNotFound(err), "stale child should be deleted")
+	assert.Equal(t, "Normal ChildUpdated Updated ConfigMap config-a", <-recorder.Events)
+	assert.Equal(t, "Normal ChildDeleted Deleted ConfigMap config-b", <-recorder.Events)
+}
+
+func TestChildrenReconcileAfterRestart(t *testing.T) {
+	ctx := context.Background()
+	obj := newTestObject(t)
+
+	c := fake.NewClientBuilder().Build()
+	cr := newChildrenReconciler(tRegistration, c, record.NewFakeRecorder(10), tlogr.NewTestLogger(t))
+
+	err := cr.Reconcile(ctx, obj, map[string]*unstructured.Unstructured{
+		"config": newTestConfigMap("config-a"),
+	})
+	require.NoError(t, err)
+
+	u := obj.AsKubeObject().(*unstructured.Unstructured)
+	kinds, _, _ := unstructured.NestedString(u.Object, "status", "annotations", reconciler.StatusAnnotationChildrenKinds)
+	assert.Equal(t, "v1/ConfigMap", kinds)
+
+	// A fresh reconciler does not know about the ConfigMap kind, which is
+	// read from the instance status.
+	cr = newChildrenReconciler(tRegistration, c, record.NewFakeRecorder(10), tlogr.NewTestLogger(t))
+	require.NoError(t, cr.Reconcile(ctx, obj, map[string]*unstructured.Unstructured{}))
+
+	err = c.Get(ctx, client.ObjectKey{Namespace: tNamespace, Name: "config-a"}, &corev1.ConfigMap{})
+	assert.True(t, apierrs.IsNotFound(err), "stale child should be deleted after restart")
+
+	kinds, _, _ = unstructured.NestedString(u.Object, "status", "annotations", reconciler.StatusAnnotationChildrenKinds)
+	assert.Empty(t, kinds)
+}
+
+func TestChildrenReconcileErrors(t *testing.T) {
+	testCases := map[string]struct {
+		key      string
+		child    func() *unstructured.Unstructured
+		existing []client.Object
+		expected string
+	}{
+		"invalid key": {
+			key:      "not a label",
+			child:    func() *unstructured.Unstructured { return newTestConfigMap("config") },
+			expected: "is not valid",
+		},
+		"missing name": {
+			key:      "config",
+			child:    func() *unstructured.Unstructured { return newTestConfigMap("") },
+			expected: "must inform apiVersion, kind and name",
+		},
+		"different namespace": {
+			key: "config",
+			child: func() *unstructured.Unstructured {
+				u := newTestConfigMap("config")
+				u.SetNamespace("other")
+				return u
+			},
+			expected: "does not match the object's namespace",
+		},
+		"not controlled": {
+			key:   "config",
+			child: func() *unstructured.Unstructured { return newTestConfigMap("config") },
+			existing: []client.Object{
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: tNamespace, Name: "config"}},
+			},
+			expected: "is not controlled by",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithObjects(tc.existing...).Build()
+			cr := newChildrenReconciler(tRegistration, c, record.NewFakeRecorder(10), tlogr.NewTestLogger(t))
+
+			err := cr.Reconcile(context.Background(), newTestObject(t), map[string]*unstructured.Unstructured{
+				tc.key: tc.child(),
+			})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
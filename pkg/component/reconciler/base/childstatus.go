@@ -0,0 +1,165 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package base
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	commonv1alpha1 "github.com/triggermesh/scoby/pkg/apis/common/v1alpha1"
+	"github.com/triggermesh/scoby/pkg/component/reconciler"
+)
+
+// renderChildrenStatus fills the status elements whose value is sourced
+// from reconciled children. Values are read from the live objects and
+// written preserving their type.
+func (b *base) renderChildrenStatus(ctx context.Context, obj reconciler.Object, children map[string]*unstructured.Unstructured) error {
+	live := make(map[string]*unstructured.Unstructured)
+	errs := []string{}
+
+	for _, sae := range b.childStatus {
+		vfc := sae.ValueFrom.Child
+
+		desired, ok := children[vfc.Name]
+		if !ok || desired == nil {
+			continue
+		}
+
+		u, ok := live[vfc.Name]
+		if !ok {
+			var err error
+			if u, err = b.getLiveChild(ctx, desired); err != nil {
+				errs = append(errs, err.Error())
+				continue
+			}
+			live[vfc.Name] = u
+		}
+
+		if u == nil {
+			continue
+		}
+
+		v, ok, err := nestedValue(u.Object, vfc.Path)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("child %q path %q: %v", vfc.Name, vfc.Path, err))
+			continue
+		}
+
+		// Elements that are not present are not written. Kubernetes
+		// objects usually omit zero values.
+		if !ok {
+			continue
+		}
+
+		if err := obj.GetStatusManager().SetValue(v, strings.Split(sae.Path, ".")...); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) != 0 {
+		return fmt.Errorf("could not render status from children: %s", strings.Join(errs, ". "))
+	}
+
+	return nil
+}
+
+// getLiveChild retrieves the existing object for the child. Types known by
+// the scheme are read using typed objects, which are served from the
+// controller's cache.
+func (b *base) getLiveChild(ctx context.Context, desired *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	gvk := desired.GroupVersionKind()
+	key := client.ObjectKeyFromObject(desired)
+
+	var u *unstructured.Unstructured
+	ro, err := b.client.Scheme().New(gvk)
+	if co, ok := ro.(client.Object); err == nil && ok {
+		if err = b.client.Get(ctx, key, co); err == nil {
+			var uo map[string]interface{}
+			if uo, err = runtime.DefaultUnstructuredConverter.ToUnstructured(co); err == nil {
+				u = &unstructured.Unstructured{Object: uo}
+			}
+		}
+	} else {
+		u = &unstructured.Unstructured{}
+		u.SetGroupVersionKind(gvk)
+		err = b.client.Get(ctx, key, u)
+	}
+
+	switch {
+	case apierrs.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("could not retrieve child %s %s: %w", gvk.Kind, key, err)
+	}
+
+	return u, nil
+}
+
+// nestedValue returns the element at the JSON simplified path. Array items
+// are referenced using the index between brackets, like
+// spec.template.spec.containers[0].image
+func nestedValue(obj map[string]interface{}, path string) (interface{}, bool, error) {
+	var current interface{} = obj
+
+	for _, field := range strings.Split(path, ".") {
+		name, index := field, -1
+		if i := strings.Index(field, "["); i != -1 && strings.HasSuffix(field, "]") {
+			n, err := strconv.Atoi(field[i+1 : len(field)-1])
+			if err != nil || n < 0 {
+				return nil, false, fmt.Errorf("invalid index at %q", field)
+			}
+			name, index = field[:i], n
+		}
+
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false, fmt.Errorf("element %q is not an object", name)
+		}
+
+		if current, ok = m[name]; !ok {
+			return nil, false, nil
+		}
+
+		if index == -1 {
+			continue
+		}
+
+		l, ok := current.([]interface{})
+		if !ok {
+			return nil, false, fmt.Errorf("element %q is not an array", name)
+		}
+
+		if index >= len(l) {
+			return nil, false, nil
+		}
+		current = l[index]
+	}
+
+	return runtime.DeepCopyJSONValue(current), true, nil
+}
+
+// childStatusElements returns the status elements sourced from children.
+func childStatusElements(reg commonv1alpha1.Registration) []commonv1alpha1.StatusAddElement {
+	wkl := reg.GetWorkload()
+	if wkl == nil || wkl.StatusConfiguration == nil {
+		return nil
+	}
+
+	var elements []commonv1alpha1.StatusAddElement
+	for _, sae := range wkl.StatusConfiguration.AddElements {
+		if sae.ValueFrom.Child != nil {
+			elements = append(elements, sae)
+		}
+	}
+
+	return elements
+}
@@ -0,0 +1,160 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package base
+
+import (
+	"context"
+	"testing"
+
+	tlogr "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	commonv1alpha1 "github.com/triggermesh/scoby/pkg/apis/common/v1alpha1"
+)
+
+func TestNestedValue(t *testing.T) {
+	obj := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas": int64(2),
+			"containers": []interface{}{
+				map[string]interface{}{"image": "kuard:v1"},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		path          string
+		expected      interface{}
+		expectedFound bool
+		expectedError string
+	}{
+		"integer": {
+			path:          "spec.replicas",
+			expected:      int64(2),
+			expectedFound: true,
+		},
+		"array item": {
+			path:          "spec.containers[0].image",
+			expected:      "kuard:v1",
+			expectedFound: true,
+		},
+		"missing element": {
+			path: "status.readyReplicas",
+		},
+		"index out of range": {
+			path: "spec.containers[1].image",
+		},
+		"invalid index": {
+			path:          "spec.containers[a].image",
+			expectedError: "invalid index",
+		},
+		"not an array": {
+			path:          "spec.replicas[0]",
+			expectedError: "is not an array",
+		},
+		"not an object": {
+			path:          "spec.replicas.value",
+			expectedError: "is not an object",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			v, found, err := nestedValue(obj, tc.path)
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedFound, found)
+			assert.Equal(t, tc.expected, v)
+		})
+	}
+}
+
+func TestRenderChildrenStatus(t *testing.T) {
+	replicas := int32(1)
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: tNamespace, Name: tName},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "adapter", Image: "kuard:v1"}},
+				},
+			},
+		},
+		Status: appsv1.DeploymentStatus{ReadyReplicas: 1},
+	}
+
+	desired := &unstructured.Unstructured{}
+	desired.SetAPIVersion("apps/v1")
+	desired.SetKind("Deployment")
+	desired.SetNamespace(tNamespace)
+	desired.SetName(tName)
+
+	b := &base{
+		client: fake.NewClientBuilder().WithObjects(d).Build(),
+		childStatus: []commonv1alpha1.StatusAddElement{
+			{
+				Path: "status.readyReplicas",
+				ValueFrom: commonv1alpha1.StatusValueFrom{
+					Child: &commonv1alpha1.StatusValueFromChild{Name: "deployment", Path: "status.readyReplicas"},
+				},
+			},
+			{
+				Path: "status.image",
+				ValueFrom: commonv1alpha1.StatusValueFrom{
+					Child: &commonv1alpha1.StatusValueFromChild{Name: "deployment", Path: "spec.template.spec.containers[0].image"},
+				},
+			},
+			{
+				Path: "status.unavailable",
+				ValueFrom: commonv1alpha1.StatusValueFrom{
+					Child: &commonv1alpha1.StatusValueFromChild{Name: "deployment", Path: "status.unavailableReplicas"},
+				},
+			},
+			{
+				Path: "status.clusterIP",
+				ValueFrom: commonv1alpha1.StatusValueFrom{
+					Child: &commonv1alpha1.StatusValueFromChild{Name: "service", Path: "spec.clusterIP"},
+				},
+			},
+		},
+		log: tlogr.NewTestLogger(t),
+	}
+
+	obj := newTestObject(t)
+	err := b.renderChildrenStatus(context.Background(), obj, map[string]*unstructured.Unstructured{
+		"deployment": desired,
+		"service":    nil,
+	})
+	require.NoError(t, err)
+
+	u := obj.AsKubeObject().(*unstructured.Unstructured)
+
+	rr, found, err := unstructured.NestedInt64(u.Object, "status", "readyReplicas")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, int64(1), rr)
+
+	image, _, _ := unstructured.NestedString(u.Object, "status", "image")
+	assert.Equal(t, "kuard:v1", image)
+
+	_, found, _ = unstructured.NestedFieldNoCopy(u.Object, "status", "unavailable")
+	assert.False(t, found, "missing child elements should not be written")
+
+	_, found, _ = unstructured.NestedFieldNoCopy(u.Object, "status", "clusterIP")
+	assert.False(t, found, "omitted children should not be written")
+}
@@ -4,12 +4,14 @@
 package crd
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 
+	commonv1alpha1 "github.com/triggermesh/scoby/pkg/apis/common/v1alpha1"
 	. "github.com/triggermesh/scoby/test"
 )
 
@@ -379,6 +381,48 @@ spec:
                     - type
                     type: object
                   type: array
+              type: object`),
+			allowConditions:         true,
+			allowObservedGeneration: false,
+			allowAnnotations:        false,
+			allowAddressURL:         false,
+		},
+		"conditions without message": {
+			in: ReadCRD(`
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: kuards.extensions.triggermesh.io
+spec:
+  group: extensions.triggermesh.io
+  scope: Namespaced
+  names:
+    plural: kuards
+    singular: kuard
+    kind: Kuard
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      subresources:
+        status: {}
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            status:
+              properties:
+                conditions:
+                  items:
+                    properties:
+                      reason:
+                        type: string
+                      status:
+                        type: string
+                      type:
+                        type: string
+                    type: object
+                  type: array
               type: object`),
 			allowConditions:         true,
 			allowObservedGeneration: false,
@@ -463,3 +507,123 @@ spec:
 		})
 	}
 }
+
+func TestHasStringField(t *testing.T) {
+	crdv := CRDPrioritizedVersion(ReadCRD(`
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: kuards.extensions.triggermesh.io
+spec:
+  group: extensions.triggermesh.io
+  scope: Namespaced
+  names:
+    plural: kuards
+    singular: kuard
+    kind: Kuard
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      subresources:
+        status: {}
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            status:
+              properties:
+                endpoint:
+                  type: string
+                replicas:
+                  type: integer
+              type: object`))
+
+	assert.True(t, HasStringField(crdv, "status.endpoint"))
+	assert.False(t, HasStringField(crdv, "status.replicas"), "integer elements are not strings")
+	assert.False(t, HasStringField(crdv, "status.phase"), "not declared elements are not strings")
+	assert.False(t, HasStringField(crdv, "status"), "objects are not strings")
+}
+
+func TestStatusFlagCapabilities(t *testing.T) {
+	testCases := map[string]struct {
+		in       StatusFlag
+		expected []string
+	}{
+		"none": {
+			in:       0,
+			expected: []string{},
+		},
+		"partial conditions": {
+			in:       StatusFlagConditionType,
+			expected: []string{},
+		},
+		"all": {
+			in: StatusFlagObservedGeneration | StatusFlagAnnotations |
+				StatusFlagConditionType | StatusFlagConditionStatus | StatusFlagAddressURL,
+			expected: []string{"observedGeneration", "annotations", "conditions", "address"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.in.Capabilities())
+		})
+	}
+}
+
+func TestCRDSelectedVersion(t *testing.T) {
+	crd := func(storageServed bool) *apiextensionsv1.CustomResourceDefinition {
+		return ReadCRD(fmt.Sprintf(`
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: kuards.extensions.triggermesh.io
+spec:
+  group: extensions.triggermesh.io
+  scope: Namespaced
+  names:
+    plural: kuards
+    singular: kuard
+    kind: Kuard
+  versions:
+    - name: v1alpha1
+      served: %t
+      storage: true
+    - name: v1beta1
+      served: true
+      storage: false`, storageServed))
+	}
+
+	testCases := map[string]struct {
+		in        *apiextensionsv1.CustomResourceDefinition
+		selection commonv1alpha1.VersionSelection
+		expected  string
+	}{
+		"default": {
+			in:       crd(true),
+			expected: "v1beta1",
+		},
+		"preferred": {
+			in:        crd(true),
+			selection: commonv1alpha1.VersionSelectionPreferred,
+			expected:  "v1beta1",
+		},
+		"storage": {
+			in:        crd(true),
+			selection: commonv1alpha1.VersionSelectionStorage,
+			expected:  "v1alpha1",
+		},
+		"storage not served": {
+			in:        crd(false),
+			selection: commonv1alpha1.VersionSelectionStorage,
+			expected:  "v1beta1",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, CRDSelectedVersion(tc.in, tc.selection).Name)
+		})
+	}
+}
@@ -4,8 +4,12 @@
 package crd
 
 import (
+	"strings"
+
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/version"
+
+	commonv1alpha1 "github.com/triggermesh/scoby/pkg/apis/common/v1alpha1"
 )
 
 func CRDPrioritizedVersion(crd *apiextensionsv1.CustomResourceDefinition) *apiextensionsv1.CustomResourceDefinitionVersion {
@@ -14,14 +18,37 @@ func CRDPrioritizedVersion(crd *apiextensionsv1.CustomResourceDefinition) *apiex
 	}
 
 	var crdv *apiextensionsv1.CustomResourceDefinitionVersion = &crd.Spec.Versions[0]
-	for _, v := range crd.Spec.Versions {
+	for i := range crd.Spec.Versions {
+		v := &crd.Spec.Versions[i]
 		if v.Served && !v.Deprecated && version.CompareKubeAwareVersionStrings(v.Name, crdv.Name) > 0 {
-			crdv = &v
+			crdv = v
 		}
 	}
 	return crdv
 }
 
+// CRDStorageVersion returns the storage version when it is served.
+func CRDStorageVersion(crd *apiextensionsv1.CustomResourceDefinition) *apiextensionsv1.CustomResourceDefinitionVersion {
+	for i := range crd.Spec.Versions {
+		if v := &crd.Spec.Versions[i]; v.Storage && v.Served {
+			return v
+		}
+	}
+	return nil
+}
+
+// CRDSelectedVersion returns the CRD version according to the selection
+// policy. When the storage version is not served the prioritized version
+// is returned.
+func CRDSelectedVersion(crd *apiextensionsv1.CustomResourceDefinition, selection commonv1alpha1.VersionSelection) *apiextensionsv1.CustomResourceDefinitionVersion {
+	if selection == commonv1alpha1.VersionSelectionStorage {
+		if crdv := CRDStorageVersion(crd); crdv != nil {
+			return crdv
+		}
+	}
+	return CRDPrioritizedVersion(crd)
+}
+
 type StatusFlag uint16
 
 const (
@@ -33,16 +60,35 @@ const (
 	StatusFlagConditionStatus
 	StatusFlagConditionType
 	StatusFlagAddressURL
+	StatusFlagPublicURL
 )
 
+// AllowConditions returns true when conditions contain at least the
+// type and status elements. Reason, message and lastTransitionTime are
+// only written when declared at the schema.
 func (sf StatusFlag) AllowConditions() bool {
-	return sf&StatusFlagConditionLastTranstitionTime != 0 &&
-		sf&StatusFlagConditionMessage != 0 &&
-		sf&StatusFlagConditionReason != 0 &&
-		sf&StatusFlagConditionStatus != 0 &&
+	return sf&StatusFlagConditionStatus != 0 &&
 		sf&StatusFlagConditionType != 0
 }
 
+// AllowConditionField returns true when the condition element,
+// one of reason, message or lastTransitionTime, is declared.
+func (sf StatusFlag) AllowConditionField(field string) bool {
+	switch field {
+	case "type":
+		return sf&StatusFlagConditionType != 0
+	case "status":
+		return sf&StatusFlagConditionStatus != 0
+	case "reason":
+		return sf&StatusFlagConditionReason != 0
+	case "message":
+		return sf&StatusFlagConditionMessage != 0
+	case "lastTransitionTime":
+		return sf&StatusFlagConditionLastTranstitionTime != 0
+	}
+	return false
+}
+
 func (sf StatusFlag) AllowAnnotations() bool {
 	return sf&StatusFlagAnnotations != 0
 }
@@ -55,6 +101,32 @@ func (sf StatusFlag) AllowAddressURL() bool {
 	return sf&StatusFlagAddressURL != 0
 }
 
+func (sf StatusFlag) AllowPublicURL() bool {
+	return sf&StatusFlagPublicURL != 0
+}
+
+// Capabilities returns the names of the status elements supported.
+func (sf StatusFlag) Capabilities() []string {
+	caps := []string{}
+	if sf.AllowObservedGeneration() {
+		caps = append(caps, "observedGeneration")
+	}
+	if sf.AllowAnnotations() {
+		caps = append(caps, "annotations")
+	}
+	if sf.AllowConditions() {
+		caps = append(caps, "conditions")
+	}
+	if sf.AllowAddressURL() {
+		caps = append(caps, "address")
+	}
+	if sf.AllowPublicURL() {
+		caps = append(caps, "url")
+	}
+
+	return caps
+}
+
 func CRDStatusFlag(crdv *apiextensionsv1.CustomResourceDefinitionVersion) StatusFlag {
 	var sf StatusFlag = 0
 
@@ -79,10 +151,12 @@ func CRDStatusFlag(crdv *apiextensionsv1.CustomResourceDefinitionVersion) Status
 		sf |= StatusFlagAnnotations
 	}
 
-	if address, ok := status.Properties["address"]; ok && address.Type == "object" {
-		if url, ok := address.Properties["url"]; ok && url.Type == "string" {
-			sf |= StatusFlagAddressURL
-		}
+	if HasStringField(crdv, "status.address.url") {
+		sf |= StatusFlagAddressURL
+	}
+
+	if HasStringField(crdv, "status.url") {
+		sf |= StatusFlagPublicURL
 	}
 
 	conditions, ok := status.Properties["conditions"]
@@ -118,3 +192,22 @@ func CRDStatusFlag(crdv *apiextensionsv1.CustomResourceDefinitionVersion) Status
 
 	return sf
 }
+
+// HasStringField returns true when the JSON simplified path
+// is declared as a string at the CRD version schema.
+func HasStringField(crdv *apiextensionsv1.CustomResourceDefinitionVersion, path string) bool {
+	if crdv.Schema == nil || crdv.Schema.OpenAPIV3Schema == nil {
+		return false
+	}
+
+	props := crdv.Schema.OpenAPIV3Schema
+	for _, f := range strings.Split(path, ".") {
+		p, ok := props.Properties[f]
+		if !ok {
+			return false
+		}
+		props = &p
+	}
+
+	return props.Type == "string"
+}
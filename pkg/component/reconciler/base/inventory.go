@@ -0,0 +1,80 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package base
+
+import (
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Inventory keeps track of the instances managed by a controller, their
+// readiness and the last rendering error. It is kept in memory so that
+// the registration can summarize its instances without listing them.
+type Inventory struct {
+	lock      sync.RWMutex
+	instances map[types.NamespacedName]bool
+
+	lastRenderErrorKey types.NamespacedName
+	lastRenderError    string
+}
+
+// NewInventory creates an empty inventory.
+func NewInventory() *Inventory {
+	return &Inventory{
+		instances: make(map[types.NamespacedName]bool),
+	}
+}
+
+// Set informs the readiness of an instance.
+func (i *Inventory) Set(key types.NamespacedName, ready bool) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.instances[key] = ready
+}
+
+// Delete removes the instance from the inventory.
+func (i *Inventory) Delete(key types.NamespacedName) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	delete(i.instances, key)
+	if i.lastRenderErrorKey == key {
+		i.lastRenderErrorKey = types.NamespacedName{}
+		i.lastRenderError = ""
+	}
+}
+
+// SetRenderError keeps the last rendering error. A nil error clears the
+// last error when it was informed for the same instance.
+func (i *Inventory) SetRenderError(key types.NamespacedName, err error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	switch {
+	case err != nil:
+		i.lastRenderErrorKey = key
+		i.lastRenderError = fmt.Sprintf("%s: %v", key, err)
+	case i.lastRenderErrorKey == key:
+		i.lastRenderErrorKey = types.NamespacedName{}
+		i.lastRenderError = ""
+	}
+}
+
+// Summary returns the number of instances, ready instances and the last
+// rendering error.
+func (i *Inventory) Summary() (instances, ready int, lastRenderError string) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	for _, r := range i.instances {
+		if r {
+			ready++
+		}
+	}
+
+	return len(i.instances), ready, i.lastRenderError
+}
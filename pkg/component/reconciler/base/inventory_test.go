@@ -0,0 +1,39 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package base
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestInventory(t *testing.T) {
+	inv := NewInventory()
+	a := types.NamespacedName{Namespace: "ns", Name: "a"}
+	b := types.NamespacedName{Namespace: "ns", Name: "b"}
+
+	inv.Set(a, true)
+	inv.Set(b, false)
+	inv.SetRenderError(b, errors.New("bad template"))
+
+	instances, ready, lastErr := inv.Summary()
+	assert.Equal(t, 2, instances)
+	assert.Equal(t, 1, ready)
+	assert.Equal(t, "ns/b: bad template", lastErr)
+
+	// Success for other instance does not clear the error.
+	inv.SetRenderError(a, nil)
+	_, _, lastErr = inv.Summary()
+	assert.Equal(t, "ns/b: bad template", lastErr)
+
+	inv.Delete(b)
+	instances, ready, lastErr = inv.Summary()
+	assert.Equal(t, 1, instances)
+	assert.Equal(t, 1, ready)
+	assert.Empty(t, lastErr)
+}
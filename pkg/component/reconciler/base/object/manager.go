@@ -37,8 +37,19 @@ func (m manager) NewObject() reconciler.Object {
 		evsByPath: make(map[string]*corev1.EnvVar),
 		evsByName: make(map[string]*corev1.EnvVar),
 
+		evContainersByPath: make(map[string]commonv1alpha1.ContainerSelector),
+
 		vmByPath: make(map[string]*commonv1alpha1.FromSpecToVolume),
 		vmByName: make(map[string]*commonv1alpha1.FromSpecToVolume),
+
+		container:  &commonv1alpha1.ContainerSettings{},
+		scheduling: &commonv1alpha1.SchedulingSettings{},
+		security:   &commonv1alpha1.SecurityConfiguration{},
+		image: &imageSettings{
+			copies: make(map[string]string),
+		},
+		serviceAccountName: new(string),
+		additional:         &additionalContainers{},
 	}
 }
 
@@ -16,15 +16,26 @@ import (
 	"github.com/triggermesh/scoby/pkg/utils/resources"
 )
 
+const (
+	// tmpVolumeName is the name of the emptyDir volume mounted at /tmp.
+	tmpVolumeName = "tmp"
+	tmpMountPath  = "/tmp"
+)
+
 var (
-	securityContext = resources.NewSecurityContext(
+	// defaultSecurityContext complies with the restricted Pod Security
+	// Standard, set at the container so that it is accepted by form
+	// factors that do not allow pod security contexts.
+	defaultSecurityContext = resources.NewSecurityContext(
 		resources.SecurityContextWithPrivilegeEscalation(false),
 		resources.SecurityContextWithReadOnlyRootFilesystem(true),
+		resources.SecurityContextWithRunAsNonRoot(true),
+		resources.SecurityContextWithDropCapabilities("ALL"),
+		resources.SecurityContextWithSeccompProfile(corev1.SeccompProfileTypeRuntimeDefault),
 	)
 
 	defaultContainerOpts = []resources.ContainerOption{
 		resources.ContainerWithTerminationMessagePolicy(corev1.TerminationMessageFallbackToLogsOnError),
-		resources.ContainerWithSecurityContext(securityContext),
 	}
 )
 
@@ -43,8 +54,45 @@ type object struct {
 	evsByPath map[string]*corev1.EnvVar
 	evsByName map[string]*corev1.EnvVar
 
+	// Containers selected by rendering rules for the environment
+	// variables, mapped by their JSON path.
+	evContainersByPath map[string]commonv1alpha1.ContainerSelector
+
 	vmByPath map[string]*commonv1alpha1.FromSpecToVolume
 	vmByName map[string]*commonv1alpha1.FromSpecToVolume
+
+	// Settings for the workload container.
+	container *commonv1alpha1.ContainerSettings
+
+	// Scheduling settings for the workload pods.
+	scheduling *commonv1alpha1.SchedulingSettings
+
+	// Security settings for the workload.
+	security *commonv1alpha1.SecurityConfiguration
+
+	// Image settings for the workload.
+	image *imageSettings
+
+	// ServiceAccount the workload runs as.
+	serviceAccountName *string
+
+	// Init containers and sidecars for the workload pods.
+	additional *additionalContainers
+}
+
+type additionalContainers struct {
+	initContainers []commonv1alpha1.AdditionalContainer
+	sidecars       []commonv1alpha1.AdditionalContainer
+}
+
+type imageSettings struct {
+	image      string
+	pullPolicy corev1.PullPolicy
+
+	// pullSecrets in the order they were added, and those
+	// that are copied from the Scoby namespace indexed by name.
+	pullSecrets []string
+	copies      map[string]string
 }
 
 var _ reconciler.Object = (*object)(nil)
@@ -69,29 +117,177 @@ func (o object) AddVolumeMount(fromPath string, vm *commonv1alpha1.FromSpecToVol
 	o.vmByName[vm.Name] = vm
 }
 
+func (o object) SetEnvVarContainers(fromPath string, cs commonv1alpha1.ContainerSelector) {
+	o.evContainersByPath[fromPath] = cs
+}
+
+func (o object) SetContainerSettings(cs *commonv1alpha1.ContainerSettings) {
+	*o.container = *cs
+}
+
+func (o object) SetSchedulingSettings(ss *commonv1alpha1.SchedulingSettings) {
+	*o.scheduling = *ss
+}
+
+func (o object) SetSecuritySettings(sc *commonv1alpha1.SecurityConfiguration) {
+	*o.security = *sc
+}
+
+func (o object) SetImage(image string, pullPolicy corev1.PullPolicy) {
+	o.image.image = image
+	o.image.pullPolicy = pullPolicy
+}
+
+func (o object) AddImagePullSecret(name, copyFrom string) {
+	for _, s := range o.image.pullSecrets {
+		if s == name {
+			return
+		}
+	}
+	o.image.pullSecrets = append(o.image.pullSecrets, name)
+
+	if copyFrom != "" {
+		o.image.copies[name] = copyFrom
+	}
+}
+
+func (o object) SetAdditionalContainers(initContainers, sidecars []commonv1alpha1.AdditionalContainer) {
+	o.additional.initContainers = initContainers
+	o.additional.sidecars = sidecars
+}
+
+func (o object) SetServiceAccountName(name string) {
+	*o.serviceAccountName = name
+}
+
+func (o object) GetImagePullSecretCopies() map[string]string {
+	return o.image.copies
+}
+
 func (o object) AsContainerOptions() []resources.ContainerOption {
+	// Initialize array of options and add default set.
+	copts := make([]resources.ContainerOption, 0, len(o.evsByName)+len(defaultContainerOpts))
+	copts = append(copts, defaultContainerOpts...)
+	copts = append(copts, o.renderedContainerOptions(reconciler.DefaultContainerName, true)...)
+	copts = append(copts, resources.ContainerWithSecurityContext(o.containerSecurityContext()))
+	copts = append(copts, containerSettingsOptions(o.container)...)
+
+	if o.image.image != "" {
+		copts = append(copts, resources.ContainerWithImage(o.image.image))
+	}
+	if o.image.pullPolicy != "" {
+		copts = append(copts, resources.ContainerWithImagePullPolicy(o.image.pullPolicy))
+	}
+
+	return copts
+}
+
+// renderedContainerOptions returns the environment variables and volume
+// mounts rendered from the instance that are selected for the container.
+func (o object) renderedContainerOptions(container string, share bool) []resources.ContainerOption {
+	// Environment variables are indexed by name, the path of each one
+	// is needed to check the containers selected for it.
+	pathsByEnv := make(map[*corev1.EnvVar]string, len(o.evsByPath))
+	for p, ev := range o.evsByPath {
+		pathsByEnv[ev] = p
+	}
+
 	envNames := make([]string, 0, len(o.evsByName))
 	for k := range o.evsByName {
 		envNames = append(envNames, k)
 	}
 	sort.Strings(envNames)
 
-	// Initialize array of options and add default set.
-	copts := make([]resources.ContainerOption, 0, len(o.evsByName)+len(defaultContainerOpts))
-	copts = append(copts, defaultContainerOpts...)
-
+	copts := []resources.ContainerOption{}
 	for _, k := range envNames {
 		ev := o.evsByName[k]
+		if !o.evContainersByPath[pathsByEnv[ev]].Selects(container, share) {
+			continue
+		}
 		copts = append(copts, resources.ContainerAddEnv(ev))
 	}
 
 	for i := range o.vmByName {
 		v := o.vmByName[i]
+		if !v.Containers.Selects(container, share) {
+			continue
+		}
 		copts = append(copts, resources.ContainerAddVolumeMount(
 			resources.NewVolumeMount(v.Name, v.MountPath),
 		))
 	}
 
+	if o.security.WritableTmp {
+		copts = append(copts, resources.ContainerAddVolumeMount(
+			resources.NewVolumeMount(tmpVolumeName, tmpMountPath)))
+	}
+
+	return copts
+}
+
+// containerSecurityContext returns the security context for the
+// workload container.
+func (o object) containerSecurityContext() *corev1.SecurityContext {
+	if o.security.Container != nil {
+		return o.security.Container
+	}
+	return defaultSecurityContext.DeepCopy()
+}
+
+// asAdditionalContainer creates an init container or sidecar.
+func (o object) asAdditionalContainer(ac *commonv1alpha1.AdditionalContainer) *corev1.Container {
+	copts := make([]resources.ContainerOption, 0, len(defaultContainerOpts))
+	copts = append(copts, defaultContainerOpts...)
+
+	for i := range ac.Env {
+		copts = append(copts, resources.ContainerAddEnv(&ac.Env[i]))
+	}
+	copts = append(copts, o.renderedContainerOptions(ac.Name, ac.ShareRendered)...)
+
+	if len(ac.Command) != 0 {
+		copts = append(copts, resources.ContainerWithCommand(ac.Command...))
+	}
+	if len(ac.Args) != 0 {
+		copts = append(copts, resources.ContainerWithArgs(ac.Args...))
+	}
+
+	if ac.SecurityContext != nil {
+		copts = append(copts, resources.ContainerWithSecurityContext(ac.SecurityContext))
+	} else {
+		copts = append(copts, resources.ContainerWithSecurityContext(o.containerSecurityContext()))
+	}
+
+	copts = append(copts, containerSettingsOptions(&ac.ContainerSettings)...)
+
+	if ac.PullPolicy != "" {
+		copts = append(copts, resources.ContainerWithImagePullPolicy(ac.PullPolicy))
+	}
+
+	return resources.NewContainer(ac.Name, ac.Image, copts...)
+}
+
+// containerSettingsOptions returns the options for the container settings.
+func containerSettingsOptions(cs *commonv1alpha1.ContainerSettings) []resources.ContainerOption {
+	copts := []resources.ContainerOption{}
+	if cs.Resources != nil {
+		copts = append(copts, resources.ContainerWithResources(cs.Resources))
+	}
+	if len(cs.Ports) != 0 {
+		copts = append(copts, resources.ContainerAddPorts(cs.Ports...))
+	}
+	if cs.LivenessProbe != nil {
+		copts = append(copts, resources.ContainerWithLivenessProbe(cs.LivenessProbe))
+	}
+	if cs.ReadinessProbe != nil {
+		copts = append(copts, resources.ContainerWithReadinessProbe(cs.ReadinessProbe))
+	}
+	if cs.StartupProbe != nil {
+		copts = append(copts, resources.ContainerWithStartupProbe(cs.StartupProbe))
+	}
+	if cs.Lifecycle != nil {
+		copts = append(copts, resources.ContainerWithLifecycle(cs.Lifecycle))
+	}
+
 	return copts
 }
 
@@ -124,6 +320,51 @@ func (o object) AsPodSpecOptions() []resources.PodSpecOption {
 		// the AsContainerOptions function.
 	}
 
+	if o.security.Pod != nil {
+		psopts = append(psopts, resources.PodSpecWithSecurityContext(o.security.Pod))
+	}
+	if o.security.WritableTmp {
+		psopts = append(psopts, resources.PodSpecAddVolume(
+			resources.NewVolume(tmpVolumeName, resources.VolumeFromEmptyDirOption())))
+	}
+
+	ss := o.scheduling
+	if len(ss.NodeSelector) != 0 {
+		psopts = append(psopts, resources.PodSpecWithNodeSelector(ss.NodeSelector))
+	}
+	if ss.Affinity != nil {
+		psopts = append(psopts, resources.PodSpecWithAffinity(ss.Affinity))
+	}
+	if len(ss.Tolerations) != 0 {
+		psopts = append(psopts, resources.PodSpecWithTolerations(ss.Tolerations...))
+	}
+	if len(ss.TopologySpreadConstraints) != 0 {
+		psopts = append(psopts, resources.PodSpecWithTopologySpreadConstraints(ss.TopologySpreadConstraints...))
+	}
+	if ss.PriorityClassName != "" {
+		psopts = append(psopts, resources.PodSpecWithPriorityClassName(ss.PriorityClassName))
+	}
+
+	if len(o.image.pullSecrets) != 0 {
+		psopts = append(psopts, resources.PodSpecAddImagePullSecrets(o.image.pullSecrets...))
+	}
+
+	if *o.serviceAccountName != "" {
+		psopts = append(psopts, resources.PodSpecWithServiceAccountName(*o.serviceAccountName))
+	}
+
+	for i := range o.additional.initContainers {
+		psopts = append(psopts, resources.PodSpecAddInitContainer(
+			o.asAdditionalContainer(&o.additional.initContainers[i])))
+	}
+
+	// Sidecars are added after the workload container, which is
+	// expected to be added by the form factor first.
+	for i := range o.additional.sidecars {
+		psopts = append(psopts, resources.PodSpecAddContainer(
+			o.asAdditionalContainer(&o.additional.sidecars[i])))
+	}
+
 	return psopts
 }
 
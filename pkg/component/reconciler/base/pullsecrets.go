@@ -0,0 +1,59 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package base
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/kmeta"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/triggermesh/scoby/pkg/component/reconciler"
+	"github.com/triggermesh/scoby/pkg/utils/resources"
+)
+
+// pullSecretChildPrefix prefixes the children map key of pull secrets
+// copied from the Scoby namespace.
+const pullSecretChildPrefix = "pullsecret-"
+
+// pullSecretCopies returns the children for the pull secrets that
+// the object copies from the Scoby namespace, indexed by their key.
+func (b *base) pullSecretCopies(ctx context.Context, obj reconciler.Object) (map[string]*unstructured.Unstructured, error) {
+	copies := obj.GetImagePullSecretCopies()
+	if len(copies) == 0 {
+		return nil, nil
+	}
+
+	if b.scobyReader == nil {
+		return nil, fmt.Errorf("pull secrets cannot be copied, the Scoby namespace reader is not configured")
+	}
+
+	children := make(map[string]*unstructured.Unstructured, len(copies))
+	for name, from := range copies {
+		src := &corev1.Secret{}
+		if err := b.scobyReader.Get(ctx, client.ObjectKey{Namespace: b.scobyNamespace, Name: from}, src); err != nil {
+			return nil, fmt.Errorf("could not retrieve pull secret %s/%s: %w", b.scobyNamespace, from, err)
+		}
+
+		sopts := []resources.SecretOption{resources.SecretWithType(src.Type)}
+		for k, v := range src.Data {
+			sopts = append(sopts, resources.SecretSetData(k, v))
+		}
+
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(
+			resources.NewSecret(obj.GetNamespace(), name, sopts...))
+		if err != nil {
+			return nil, fmt.Errorf("could not convert pull secret %s: %w", name, err)
+		}
+
+		children[kmeta.ChildName(pullSecretChildPrefix, name)] = &unstructured.Unstructured{Object: u}
+	}
+
+	return children, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package base
+
+import (
+	"context"
+	"testing"
+
+	tlogr "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const tScobyNamespace = "triggermesh"
+
+func TestPullSecretCopies(t *testing.T) {
+	ctx := context.Background()
+
+	src := &corev1.Secret{
+		Type: corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{}}`)},
+	}
+	src.SetNamespace(tScobyNamespace)
+	src.SetName("registry")
+
+	sr := fake.NewClientBuilder().WithObjects(src).Build()
+
+	t.Run("no copies", func(t *testing.T) {
+		b := &base{log: tlogr.NewTestLogger(t)}
+		obj := newTestObject(t)
+		obj.AddImagePullSecret("local", "")
+
+		children, err := b.pullSecretCopies(ctx, obj)
+		require.NoError(t, err)
+		assert.Empty(t, children)
+	})
+
+	t.Run("reader not configured", func(t *testing.T) {
+		b := &base{log: tlogr.NewTestLogger(t)}
+		obj := newTestObject(t)
+		obj.AddImagePullSecret("copy", "registry")
+
+		_, err := b.pullSecretCopies(ctx, obj)
+		assert.EqualError(t, err, "pull secrets cannot be copied, the Scoby namespace reader is not configured")
+	})
+
+	t.Run("missing secret", func(t *testing.T) {
+		b := &base{scobyReader: sr, scobyNamespace: tScobyNamespace, log: tlogr.NewTestLogger(t)}
+		obj := newTestObject(t)
+		obj.AddImagePullSecret("copy", "missing")
+
+		_, err := b.pullSecretCopies(ctx, obj)
+		assert.ErrorContains(t, err, "could not retrieve pull secret triggermesh/missing")
+	})
+
+	t.Run("copy reconciled as child", func(t *testing.T) {
+		b := &base{scobyReader: sr, scobyNamespace: tScobyNamespace, log: tlogr.NewTestLogger(t)}
+		obj := newTestObject(t)
+		obj.AddImagePullSecret("copy", "registry")
+
+		children, err := b.pullSecretCopies(ctx, obj)
+		require.NoError(t, err)
+		require.Contains(t, children, "pullsecret-copy")
+
+		c := fake.NewClientBuilder().Build()
+		cr := newChildrenReconciler(tRegistration, c, record.NewFakeRecorder(10), tlogr.NewTestLogger(t))
+		require.NoError(t, cr.Reconcile(ctx, obj, children))
+
+		s := &corev1.Secret{}
+		require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: tNamespace, Name: "copy"}, s))
+		assert.Equal(t, src.Type, s.Type)
+		assert.Equal(t, src.Data, s.Data)
+
+		// Reconciling the same copy does not update the child.
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+		require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: tNamespace, Name: "copy"}, existing))
+
+		children, err = b.pullSecretCopies(ctx, obj)
+		require.NoError(t, err)
+		require.NoError(t, cr.Reconcile(ctx, obj, children))
+
+		after := &unstructured.Unstructured{}
+		after.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+		require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: tNamespace, Name: "copy"}, after))
+		assert.Equal(t, existing.GetResourceVersion(), after.GetResourceVersion())
+	})
+}
@@ -5,10 +5,18 @@ package base
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -17,37 +25,101 @@ import (
 
 	"github.com/go-logr/logr"
 	commonv1alpha1 "github.com/triggermesh/scoby/pkg/apis/common/v1alpha1"
+	hookv1 "github.com/triggermesh/scoby/pkg/apis/hook/v1"
 	"github.com/triggermesh/scoby/pkg/component/reconciler"
 	"github.com/triggermesh/scoby/pkg/utils/semantic"
 )
 
 const (
 	componentFinalizer = "scoby.triggermesh.io/finalizer"
+
+	// Max length of the error message written at status annotations.
+	maxHookErrorLength = 256
 )
 
+// ControllerOption sets optional parameters for the controller.
+type ControllerOption func(*controllerOptions)
+
+type controllerOptions struct {
+	cache      cache.Cache
+	client     client.Client
+	namespaces []string
+
+	scobyReader    client.Reader
+	scobyNamespace string
+}
+
+// WithNamespaces restricts the controller to instances at the namespaces.
+// The cache must be restricted to the same namespaces and is used to
+// watch and read instances instead of the manager's cache. The client
+// must be backed by that cache and is used to manage children.
+func WithNamespaces(c cache.Cache, cl client.Client, namespaces []string) ControllerOption {
+	return func(o *controllerOptions) {
+		o.cache = c
+		o.client = cl
+		o.namespaces = namespaces
+	}
+}
+
+// WithScobyNamespaceReader sets the reader used to retrieve objects at the
+// Scoby namespace, like pull secrets that are copied to instance namespaces.
+func WithScobyNamespaceReader(reader client.Reader, namespace string) ControllerOption {
+	return func(o *controllerOptions) {
+		o.scobyReader = reader
+		o.scobyNamespace = namespace
+	}
+}
+
 func NewController(
 	om reconciler.ObjectManager,
 	reg commonv1alpha1.Registration,
 	ffr reconciler.FormFactorReconciler,
 	hr reconciler.HookReconciler,
 	mgr ctrl.Manager,
-	log logr.Logger) (controller.Controller, error) {
+	recorder record.EventRecorder,
+	inventory *Inventory,
+	log logr.Logger,
+	opts ...ControllerOption) (controller.Controller, error) {
+
+	o := &controllerOptions{
+		cache:  mgr.GetCache(),
+		client: mgr.GetClient(),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
 
 	r := &base{
 		objectManager:        om,
 		formFactorReconciler: ffr,
 		hookReconciler:       hr,
-		client:               mgr.GetClient(),
+		childrenReconciler:   newChildrenReconciler(reg.GetName(), o.client, recorder, log),
+		childStatus:          childStatusElements(reg),
+		registration:         reg.GetName(),
+		serviceAccount:       reg.GetWorkload().ServiceAccount,
+		client:               o.client,
+		instanceReader:       o.cache,
+		recorder:             recorder,
+		inventory:            inventory,
+		scobyReader:          o.scobyReader,
+		scobyNamespace:       o.scobyNamespace,
 		log:                  log,
 	}
 
+	if len(o.namespaces) != 0 {
+		r.namespaces = make(map[string]struct{}, len(o.namespaces))
+		for _, ns := range o.namespaces {
+			r.namespaces[ns] = struct{}{}
+		}
+	}
+
 	c, err := controller.NewUnmanaged(reg.GetName(), mgr, controller.Options{Reconciler: r})
 	if err != nil {
 		return nil, fmt.Errorf("could not build controller for %q: %w", reg.GetName(), err)
 	}
 
 	obj := om.NewObject()
-	if err := c.Watch(source.Kind(mgr.GetCache(), obj.AsKubeObject()), &handler.EnqueueRequestForObject{}); err != nil {
+	if err := c.Watch(source.Kind(o.cache, obj.AsKubeObject()), &handler.EnqueueRequestForObject{}); err != nil {
 		return nil, fmt.Errorf("could not set watcher on registered object %q: %w", reg.GetName(), err)
 	}
 
@@ -64,16 +136,44 @@ type base struct {
 	objectManager        reconciler.ObjectManager
 	formFactorReconciler reconciler.FormFactorReconciler
 	hookReconciler       reconciler.HookReconciler
+	childrenReconciler   *childrenReconciler
+	childStatus          []commonv1alpha1.StatusAddElement
+	registration         string
+	serviceAccount       *commonv1alpha1.ServiceAccountConfiguration
 	client               client.Client
+	recorder             record.EventRecorder
+	inventory            *Inventory
 	log                  logr.Logger
+
+	// reader for instances, backed by the cache that watches them.
+	instanceReader client.Reader
+
+	// reader for objects at the Scoby namespace.
+	scobyReader    client.Reader
+	scobyNamespace string
+
+	// namespaces the controller is restricted to, nil when
+	// all namespaces are reconciled.
+	namespaces map[string]struct{}
 }
 
 func (b *base) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	b.log.V(1).Info("Reconciling request", "request", req)
 
+	// Requests are expected to be enqueued from caches restricted to the
+	// controller namespaces, filter them in case a watch is not.
+	if b.namespaces != nil {
+		if _, ok := b.namespaces[req.Namespace]; !ok {
+			return ctrl.Result{}, nil
+		}
+	}
+
 	// If the object does not exist, skip reconciliation.
 	obj := b.objectManager.NewObject()
-	if err := b.client.Get(ctx, req.NamespacedName, obj.AsKubeObject()); err != nil {
+	if err := b.instanceReader.Get(ctx, req.NamespacedName, obj.AsKubeObject()); err != nil {
+		if apierrs.IsNotFound(err) && b.inventory != nil {
+			b.inventory.Delete(req.NamespacedName)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
@@ -85,12 +185,20 @@ func (b *base) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, er
 
 	// Initialize status according to the form factor if needed.
 	obj.GetStatusManager().SanitizeConditions()
+	before := obj.GetStatusManager().GetHappyCondition()
 
 	var res ctrl.Result
 	var err error
 
 	if obj.GetDeletionTimestamp().IsZero() {
 		res, err = b.manageReconciliation(ctx, obj)
+		b.recordReadiness(obj, before)
+
+		if b.inventory != nil {
+			happy := obj.GetStatusManager().GetHappyCondition()
+			b.inventory.Set(req.NamespacedName,
+				err == nil && (happy == nil || happy.Status == metav1.ConditionTrue))
+		}
 
 	} else {
 		res, err = b.manageDeletion(ctx, obj)
@@ -101,7 +209,8 @@ func (b *base) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, er
 	if !semantic.Semantic.DeepEqual(
 		obj.AsKubeObject().(*unstructured.Unstructured).Object["status"],
 		cp.(*unstructured.Unstructured).Object["status"]) {
-		if uperr := b.client.Status().Update(ctx, obj.AsKubeObject()); uperr != nil {
+		// Objects whose finalizer has been removed might be gone already.
+		if uperr := client.IgnoreNotFound(b.client.Status().Update(ctx, obj.AsKubeObject())); uperr != nil {
 			if err == nil {
 				return ctrl.Result{}, uperr
 			}
@@ -121,10 +230,11 @@ func (b *base) manageDeletion(ctx context.Context, obj reconciler.Object) (ctrl.
 	// When hooks are configured we need to call Finalize on the hook and
 	// then remove the finalizer attribute at the object.
 
-	if err := b.hookReconciler.Finalize(ctx, obj); err != nil {
-		if !err.IsContinue() {
-			return ctrl.Result{Requeue: !err.IsPermanent()}, err
-		}
+	start := time.Now()
+	err := b.hookReconciler.Finalize(ctx, obj)
+	b.recordHookCall(obj, hookv1.PhaseFinalize, time.Since(start), err)
+	if err != nil && !err.IsContinue() {
+		return hookErrorResult(err)
 	}
 
 	if !controllerutil.ContainsFinalizer(obj, componentFinalizer) {
@@ -133,28 +243,67 @@ func (b *base) manageDeletion(ctx context.Context, obj reconciler.Object) (ctrl.
 
 	controllerutil.RemoveFinalizer(obj, componentFinalizer)
 
-	return ctrl.Result{}, b.client.Update(ctx, obj.AsKubeObject())
+	if err := b.client.Update(ctx, obj.AsKubeObject()); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	b.recorder.Event(obj.AsKubeObject(), corev1.EventTypeNormal, reconciler.EventReasonFinalizerRemoved,
+		"Finalizer removed after hook finalization")
+	return ctrl.Result{}, nil
 }
 
 func (b *base) manageReconciliation(ctx context.Context, obj reconciler.Object) (ctrl.Result, error) {
 	// Render using the object data and configuration
 	if err := b.objectManager.GetRenderer().Render(ctx, obj); err != nil {
-		// TODO add render status condition, write error.
+		b.updateRenderStatus(obj, reconciler.ConditionReasonRenderFailed, err)
+		return ctrl.Result{}, err
+	}
+
+	obj.SetServiceAccountName(b.serviceAccountName(obj))
+
+	pullSecrets, err := b.pullSecretCopies(ctx, obj)
+	if err != nil {
+		b.updateRenderStatus(obj, reconciler.ConditionReasonRenderFailed, err)
 		return ctrl.Result{}, err
 	}
 
 	candidates, err := b.formFactorReconciler.PreRender(ctx, obj)
 	if err != nil {
+		b.updateRenderStatus(obj, reconciler.ConditionReasonPreRenderFailed, err)
 		return ctrl.Result{}, fmt.Errorf("pre-rendering form factor children candidates: %w", err)
 	}
 
+	b.updateRenderStatus(obj, reconciler.ConditionReasonRenderOK, nil)
+
+	// Keep track of the form factor children keys, any other key returned
+	// by the hook is a child that needs to be reconciled by the base.
+	ffKeys := make(map[string]struct{}, len(candidates))
+	for k := range candidates {
+		ffKeys[k] = struct{}{}
+	}
+
 	if b.hookReconciler != nil {
 		if b.hookReconciler.IsPreReconciler() {
-			if err := b.hookReconciler.PreReconcile(ctx, obj, &candidates); err != nil {
-				if !err.IsContinue() {
-					return ctrl.Result{Requeue: !err.IsPermanent()}, fmt.Errorf("reconciling hook: %w", err)
+			start := time.Now()
+			err := b.hookReconciler.PreReconcile(ctx, obj, &candidates)
+			b.recordHookCall(obj, hookv1.PhasePreReconcile, time.Since(start), err)
+			if err != nil && !err.IsContinue() {
+				res, err := hookErrorResult(err)
+				if err != nil {
+					return res, fmt.Errorf("reconciling hook: %w", err)
 				}
+				return res, nil
 			}
+		} else {
+			// Hooks that are only called at finalization inform
+			// they are ready.
+			obj.GetStatusManager().SetCondition(&commonv1alpha1.Condition{
+				Type:               reconciler.ConditionTypeHookReady,
+				Status:             metav1.ConditionTrue,
+				Reason:             reconciler.ConditionReasonHookNotCalled,
+				Message:            "Hook is only called at finalization",
+				LastTransitionTime: metav1.Now(),
+			})
 		}
 		if b.hookReconciler.IsFinalizer() {
 			// Set the finalizer if it is not present
@@ -164,6 +313,8 @@ func (b *base) manageReconciliation(ctx context.Context, obj reconciler.Object)
 				if err := b.client.Update(ctx, objk); err != nil {
 					return ctrl.Result{}, err
 				}
+				b.recorder.Event(objk, corev1.EventTypeNormal, reconciler.EventReasonFinalizerAdded,
+					"Finalizer added for hook finalization")
 
 			}
 		}
@@ -175,6 +326,200 @@ func (b *base) manageReconciliation(ctx context.Context, obj reconciler.Object)
 		obj.GetStatusManager().SetObservedGeneration(g)
 	}
 
+	children := make(map[string]*unstructured.Unstructured)
+	for k, v := range candidates {
+		if _, ok := ffKeys[k]; !ok {
+			children[k] = v
+			delete(candidates, k)
+		}
+	}
+
+	// ServiceAccount scoped to the instance, and pull secrets are
+	// reconciled along with hook children.
+	serviceAccount, err := b.reconcileServiceAccount(ctx, obj)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("reconciling service account: %w", err)
+	}
+
+	for k, v := range serviceAccount {
+		children[k] = v
+	}
+	for k, v := range pullSecrets {
+		children[k] = v
+	}
+
+	if err := b.childrenReconciler.Reconcile(ctx, obj, children); err != nil {
+		return ctrl.Result{}, fmt.Errorf("reconciling children: %w", err)
+	}
+
 	// Pass the children candidates to the form factor for the reconcile routine.
-	return b.formFactorReconciler.Reconcile(ctx, obj, candidates)
+	res, err := b.formFactorReconciler.Reconcile(ctx, obj, candidates)
+	if err != nil || len(b.childStatus) == 0 {
+		return res, err
+	}
+
+	// Status elements from children are rendered once they are reconciled.
+	for k, v := range children {
+		candidates[k] = v
+	}
+
+	return res, b.renderChildrenStatus(ctx, obj, candidates)
+}
+
+// recordHookCall keeps track of the hook call outcome at the object status
+// annotations, events and hook condition.
+func (b *base) recordHookCall(obj reconciler.Object, phase hookv1.Phase, latency time.Duration, herr *hookv1.HookResponseError) {
+	result := reconciler.HookResultSuccess
+	msg := ""
+
+	if herr != nil {
+		msg = truncate(herr.Error(), maxHookErrorLength)
+
+		coe := &reconciler.HookCircuitOpenError{}
+		switch {
+		case errors.As(herr, &coe):
+			// Circuit open errors are not informed as events, the
+			// condition and annotations are enough to inform users
+			// and avoid flooding with events while the hook is down.
+			result = reconciler.HookResultCircuitOpen
+
+		case herr.IsContinue():
+			result = reconciler.HookResultContinued
+			b.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeWarning, reconciler.EventReasonHookContinued,
+				"Hook %s call failed, continuing reconciliation: %s", phase, msg)
+
+		default:
+			result = reconciler.HookResultFailed
+			b.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeWarning, reconciler.EventReasonHookFailed,
+				"Hook %s call failed: %s", phase, msg)
+		}
+	}
+
+	// Annotations are only written when the outcome changes. Updating the
+	// latency for each call would modify the status, triggering a new
+	// reconciliation, the latency informed is that of the call that last
+	// changed the outcome.
+	u := obj.AsKubeObject().(*unstructured.Unstructured)
+	annotations, _, _ := unstructured.NestedStringMap(u.Object, "status", "annotations")
+	if annotations[reconciler.StatusAnnotationHookLastPhase] != string(phase) ||
+		annotations[reconciler.StatusAnnotationHookLastResult] != result ||
+		annotations[reconciler.StatusAnnotationHookLastError] != msg {
+		sm := obj.GetStatusManager()
+		for k, v := range map[string]string{
+			reconciler.StatusAnnotationHookLastPhase:   string(phase),
+			reconciler.StatusAnnotationHookLastResult:  result,
+			reconciler.StatusAnnotationHookLastLatency: latency.Round(time.Millisecond).String(),
+			reconciler.StatusAnnotationHookLastError:   msg,
+		} {
+			if err := sm.SetAnnotation(k, v); err != nil {
+				b.log.Error(err, "could not set hook status annotation", "annotation", k)
+			}
+		}
+	}
+
+	c := &commonv1alpha1.Condition{
+		Type:               reconciler.ConditionTypeHookReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             reconciler.ConditionReasonHookOK,
+		LastTransitionTime: metav1.Now(),
+	}
+
+	if herr != nil {
+		c.Status = metav1.ConditionFalse
+		c.Reason = reconciler.ConditionReasonHookFailed
+		c.Message = herr.Error()
+
+		if result == reconciler.HookResultCircuitOpen {
+			c.Reason = reconciler.ConditionReasonHookCircuitOpen
+		}
+	}
+
+	obj.GetStatusManager().SetCondition(c)
+}
+
+// updateRenderStatus sets the render condition. When an error is informed
+// the condition is set to false using the reason.
+func (b *base) updateRenderStatus(obj reconciler.Object, reason string, err error) {
+	c := &commonv1alpha1.Condition{
+		Type:               reconciler.ConditionTypeRenderReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		LastTransitionTime: metav1.Now(),
+	}
+
+	if err != nil {
+		c.Status = metav1.ConditionFalse
+		c.Message = err.Error()
+		b.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeWarning, reconciler.EventReasonRenderFailed,
+			"Could not render the workload: %v", err)
+	}
+
+	if b.inventory != nil {
+		b.inventory.SetRenderError(client.ObjectKeyFromObject(obj), err)
+	}
+
+	obj.GetStatusManager().SetCondition(c)
+}
+
+// recordReadiness emits an event when the happy condition transitions
+// to True or False.
+func (b *base) recordReadiness(obj reconciler.Object, before *commonv1alpha1.Condition) {
+	after := obj.GetStatusManager().GetHappyCondition()
+	if after == nil || (before != nil && before.Status == after.Status) {
+		return
+	}
+
+	switch after.Status {
+	case metav1.ConditionTrue:
+		b.recorder.Event(obj.AsKubeObject(), corev1.EventTypeNormal, reconciler.EventReasonReady,
+			"Object is ready")
+	case metav1.ConditionFalse:
+		b.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeWarning, reconciler.EventReasonNotReady,
+			"Object is not ready: %s", notReadyConditions(obj, after.Type))
+	}
+}
+
+// notReadyConditions returns the list of False conditions and their reasons.
+func notReadyConditions(obj reconciler.Object, happyType string) string {
+	u := obj.AsKubeObject().(*unstructured.Unstructured)
+	conditions, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+
+	nr := []string{}
+	for i := range conditions {
+		c, ok := conditions[i].(map[string]interface{})
+		if !ok || c["type"] == happyType || c["status"] != string(metav1.ConditionFalse) {
+			continue
+		}
+
+		if reason, _ := c["reason"].(string); reason != "" {
+			nr = append(nr, fmt.Sprintf("%s (%s)", c["type"], reason))
+			continue
+		}
+		nr = append(nr, fmt.Sprint(c["type"]))
+	}
+
+	return strings.Join(nr, ", ")
+}
+
+// truncate shortens the message to the max number of characters.
+func truncate(msg string, max int) string {
+	r := []rune(msg)
+	if len(r) <= max {
+		return msg
+	}
+
+	return string(r[:max-3]) + "..."
+}
+
+// hookErrorResult returns the reconciliation result for a hook error.
+func hookErrorResult(herr *hookv1.HookResponseError) (ctrl.Result, error) {
+	coe := &reconciler.HookCircuitOpenError{}
+	if errors.As(herr, &coe) {
+		// Do not flood with errors while the circuit is open, the hook
+		// condition informs users and the object is requeued once the
+		// hook can be called again.
+		return ctrl.Result{RequeueAfter: coe.RetryAfter}, nil
+	}
+
+	return ctrl.Result{Requeue: !herr.IsPermanent()}, herr
 }
@@ -0,0 +1,151 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package base
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tlogr "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
+
+	commonv1alpha1 "github.com/triggermesh/scoby/pkg/apis/common/v1alpha1"
+	hookv1 "github.com/triggermesh/scoby/pkg/apis/hook/v1"
+	"github.com/triggermesh/scoby/pkg/component/reconciler"
+	"github.com/triggermesh/scoby/pkg/component/reconciler/hook"
+)
+
+func TestRecordHookCall(t *testing.T) {
+	tTrue := true
+
+	testCases := map[string]struct {
+		herr          *hookv1.HookResponseError
+		expectResult  string
+		expectError   string
+		expectedEvent string
+	}{
+		"success": {
+			expectResult: reconciler.HookResultSuccess,
+		},
+		"failed": {
+			herr:          &hookv1.HookResponseError{Message: "hook says no"},
+			expectResult:  reconciler.HookResultFailed,
+			expectError:   "hook says no",
+			expectedEvent: "Warning HookFailed Hook pre-reconcile call failed: hook says no",
+		},
+		"continued": {
+			herr:          &hookv1.HookResponseError{Message: "hook says maybe", Continue: &tTrue},
+			expectResult:  reconciler.HookResultContinued,
+			expectError:   "hook says maybe",
+			expectedEvent: "Warning HookContinued Hook pre-reconcile call failed, continuing reconciliation: hook says maybe",
+		},
+		"circuit open": {
+			herr:         &hookv1.HookResponseError{Err: &reconciler.HookCircuitOpenError{RetryAfter: time.Second}},
+			expectResult: reconciler.HookResultCircuitOpen,
+			expectError:  "hook circuit breaker is open",
+		},
+		"long error is truncated": {
+			herr:          &hookv1.HookResponseError{Message: strings.Repeat("a", 300)},
+			expectResult:  reconciler.HookResultFailed,
+			expectError:   strings.Repeat("a", maxHookErrorLength-3) + "...",
+			expectedEvent: "Warning HookFailed Hook pre-reconcile call failed: " + strings.Repeat("a", maxHookErrorLength-3) + "...",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			b := &base{
+				hookReconciler: &hook.Funcs{},
+				recorder:       recorder,
+				log:            tlogr.NewTestLogger(t),
+			}
+
+			obj := newTestObject(t)
+			b.recordHookCall(obj, hookv1.PhasePreReconcile, 1500*time.Microsecond, tc.herr)
+
+			u := obj.AsKubeObject().(*unstructured.Unstructured)
+			annotations, _, _ := unstructured.NestedStringMap(u.Object, "status", "annotations")
+			assert.Equal(t, map[string]string{
+				reconciler.StatusAnnotationHookLastPhase:   string(hookv1.PhasePreReconcile),
+				reconciler.StatusAnnotationHookLastResult:  tc.expectResult,
+				reconciler.StatusAnnotationHookLastLatency: "2ms",
+				reconciler.StatusAnnotationHookLastError:   tc.expectError,
+			}, annotations)
+
+			if tc.expectedEvent == "" {
+				assert.Empty(t, recorder.Events)
+				return
+			}
+
+			assert.Equal(t, tc.expectedEvent, <-recorder.Events)
+
+			// Same outcome does not update annotations.
+			b.recordHookCall(obj, hookv1.PhasePreReconcile, time.Second, tc.herr)
+			annotations, _, _ = unstructured.NestedStringMap(u.Object, "status", "annotations")
+			assert.Equal(t, "2ms", annotations[reconciler.StatusAnnotationHookLastLatency])
+		})
+	}
+}
+
+func TestUpdateRenderStatus(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	b := &base{recorder: recorder, log: tlogr.NewTestLogger(t)}
+	obj := newTestObject(t)
+	sm := obj.GetStatusManager()
+
+	b.updateRenderStatus(obj, reconciler.ConditionReasonRenderFailed, errors.New("missing configmap"))
+	assert.Equal(t, "Warning RenderFailed Could not render the workload: missing configmap", <-recorder.Events)
+
+	c := sm.GetCondition(reconciler.ConditionTypeRenderReady)
+	require.NotNil(t, c)
+	assert.Equal(t, metav1.ConditionFalse, c.Status)
+	assert.Equal(t, reconciler.ConditionReasonRenderFailed, c.Reason)
+	assert.Equal(t, "missing configmap", c.Message)
+	assert.Equal(t, metav1.ConditionFalse, sm.GetCondition(reconciler.ConditionTypeReady).Status)
+
+	b.updateRenderStatus(obj, reconciler.ConditionReasonRenderOK, nil)
+
+	c = sm.GetCondition(reconciler.ConditionTypeRenderReady)
+	require.NotNil(t, c)
+	assert.Equal(t, metav1.ConditionTrue, c.Status)
+	assert.Equal(t, reconciler.ConditionReasonRenderOK, c.Reason)
+	assert.Empty(t, c.Message)
+}
+
+func TestRecordReadiness(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	b := &base{recorder: recorder, log: tlogr.NewTestLogger(t)}
+	obj := newTestObject(t)
+	sm := obj.GetStatusManager()
+
+	// Transition from Unknown to False informs the failing conditions.
+	before := sm.GetHappyCondition()
+	b.updateRenderStatus(obj, reconciler.ConditionReasonRenderFailed, errors.New("missing configmap"))
+	<-recorder.Events
+	b.recordReadiness(obj, before)
+	assert.Equal(t, "Warning NotReady Object is not ready: RenderReady (RENDERFAILED)", <-recorder.Events)
+
+	// No transition, no event.
+	before = sm.GetHappyCondition()
+	b.recordReadiness(obj, before)
+	assert.Empty(t, recorder.Events)
+
+	before = sm.GetHappyCondition()
+	b.updateRenderStatus(obj, reconciler.ConditionReasonRenderOK, nil)
+	sm.SetCondition(&commonv1alpha1.Condition{
+		Type:   reconciler.ConditionTypeHookReady,
+		Status: metav1.ConditionTrue,
+		Reason: reconciler.ConditionReasonHookOK,
+	})
+	b.recordReadiness(obj, before)
+	assert.Equal(t, "Normal Ready Object is ready", <-recorder.Events)
+}
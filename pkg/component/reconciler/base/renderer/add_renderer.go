@@ -24,6 +24,9 @@ type addRenderer struct {
 	// to be parsed when rendering.
 	processEnvsByPath map[string]commonv1alpha1.AddToEnvConfiguration
 	cmr               configmap.Reader
+
+	// containers selected for environment variables.
+	containersByPath map[string]commonv1alpha1.ContainerSelector
 }
 
 func newAddRenderer(addcfg *commonv1alpha1.AddConfiguration, cmr configmap.Reader) (*addRenderer, error) {
@@ -31,6 +34,7 @@ func newAddRenderer(addcfg *commonv1alpha1.AddConfiguration, cmr configmap.Reade
 		envVarsByPath:     make(map[string]*corev1.EnvVar),
 		processEnvsByPath: make(map[string]commonv1alpha1.AddToEnvConfiguration),
 		cmr:               cmr,
+		containersByPath:  make(map[string]commonv1alpha1.ContainerSelector),
 	}
 
 	if addcfg == nil {
@@ -50,6 +54,10 @@ func newAddRenderer(addcfg *commonv1alpha1.AddConfiguration, cmr configmap.Reade
 		// using a prefix plus the variable name.
 		pseudoPath := addEnvsPrefix + ev.Name
 
+		if len(ev.Containers) != 0 {
+			ar.containersByPath[pseudoPath] = ev.Containers
+		}
+
 		switch {
 		case ev.Value != nil:
 			ar.envVarsByPath[pseudoPath] = &corev1.EnvVar{
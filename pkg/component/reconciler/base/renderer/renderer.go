@@ -8,11 +8,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
 	"sort"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/kmeta"
 
 	commonv1alpha1 "github.com/triggermesh/scoby/pkg/apis/common/v1alpha1"
 	"github.com/triggermesh/scoby/pkg/component/reconciler"
@@ -38,19 +41,123 @@ type renderer struct {
 	// TODO maybe move to status renderer object
 	addStatus []commonv1alpha1.StatusAddElement
 
+	// Mappings applied to the instance spec before rendering, so that
+	// objects created using other CRD versions can be rendered.
+	fieldMappings []commonv1alpha1.FieldMapping
+
+	// Workload container settings and overrides from the instance.
+	container *commonv1alpha1.ContainerConfiguration
+
+	// Workload pods scheduling settings and overrides from the instance.
+	scheduling *commonv1alpha1.SchedulingConfiguration
+
+	// Workload security contexts.
+	security *commonv1alpha1.SecurityConfiguration
+
+	// Workload image and overrides from the instance.
+	image commonv1alpha1.RegistrationFromImage
+
+	// Deployment autoscaling overrides from the instance.
+	autoscaling *commonv1alpha1.AutoscalingFromSpec
+
+	// Init containers and sidecars for the workload pods.
+	initContainers []commonv1alpha1.AdditionalContainer
+	sidecars       []commonv1alpha1.AdditionalContainer
+
 	add  *addRenderer
 	spec *specRenderer
 }
 
+type rendererOptions struct {
+	version string
+}
+
+// RendererOption sets optional parameters for the renderer.
+type RendererOption func(*rendererOptions)
+
+// WithCRDVersion sets the CRD version being rendered. Version rules at
+// the workload for the version are applied.
+func WithCRDVersion(version string) RendererOption {
+	return func(o *rendererOptions) {
+		o.version = version
+	}
+}
+
 // NewRenderer creates a new renderer object for reconciliation purposes.
 // The renderer needs a workload definition to parse to apply the instructions contained in it on
 // the incoming objects.
 // The resolver is needed to parse objects into URIs at built in functions.
-func NewRenderer(wkl *commonv1alpha1.Workload, resolver resolver.Resolver, cmr configmap.Reader) (reconciler.ObjectRenderer, error) {
+func NewRenderer(wkl *commonv1alpha1.Workload, resolver resolver.Resolver, cmr configmap.Reader, opts ...RendererOption) (reconciler.ObjectRenderer, error) {
 	r := &renderer{
 		resolver: resolver,
 	}
 
+	if wkl.Container != nil {
+		if fs := wkl.Container.FromSpec; fs != nil {
+			for _, p := range []string{fs.Resources, fs.LivenessProbe, fs.ReadinessProbe, fs.StartupProbe} {
+				if p != "" && !strings.HasPrefix(p, rootObject+".") {
+					return nil, fmt.Errorf("container setting paths must start with %q: %s", rootObject, p)
+				}
+			}
+		}
+		r.container = wkl.Container
+	}
+
+	if wkl.Scheduling != nil {
+		if fs := wkl.Scheduling.FromSpec; fs != nil {
+			for _, p := range schedulingPaths(fs) {
+				if p != "" && !strings.HasPrefix(p, rootObject+".") {
+					return nil, fmt.Errorf("scheduling setting paths must start with %q: %s", rootObject, p)
+				}
+			}
+		}
+		r.scheduling = wkl.Scheduling
+	}
+
+	r.security = wkl.SecurityContext
+
+	if ff := wkl.FormFactor; ff != nil && ff.Deployment != nil && ff.Deployment.Autoscaling != nil {
+		if fs := ff.Deployment.Autoscaling.FromSpec; fs != nil {
+			for _, p := range []string{fs.MinReplicas, fs.MaxReplicas} {
+				if p != "" && !strings.HasPrefix(p, rootObject+".") {
+					return nil, fmt.Errorf("autoscaling paths must start with %q: %s", rootObject, p)
+				}
+			}
+			r.autoscaling = fs
+		}
+	}
+
+	containers, err := additionalContainerNames(wkl)
+	if err != nil {
+		return nil, err
+	}
+	r.initContainers = wkl.InitContainers
+	r.sidecars = wkl.Sidecars
+
+	if fs := wkl.FromImage.FromSpec; fs != nil && !strings.HasPrefix(fs.Path, rootObject+".") {
+		return nil, fmt.Errorf("image path must start with %q: %s", rootObject, fs.Path)
+	}
+	r.image = wkl.FromImage
+
+	o := &rendererOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	pcfg := wkl.ParameterConfiguration
+	if vr := wkl.GetVersionRule(o.version); o.version != "" && vr != nil {
+		for _, fm := range vr.FieldMappings {
+			if !strings.HasPrefix(fm.From, rootObject+".") || !strings.HasPrefix(fm.To, rootObject+".") {
+				return nil, fmt.Errorf("field mapping paths must start with %q: %s to %s", rootObject, fm.From, fm.To)
+			}
+		}
+		r.fieldMappings = vr.FieldMappings
+
+		if vr.ParameterConfiguration != nil {
+			pcfg = vr.ParameterConfiguration
+		}
+	}
+
 	// Store at renderer a copy of the workload status configuration
 	if wkl.StatusConfiguration != nil {
 		scfg := wkl.StatusConfiguration
@@ -61,7 +168,6 @@ func NewRenderer(wkl *commonv1alpha1.Workload, resolver resolver.Resolver, cmr c
 		}
 	}
 
-	pcfg := wkl.ParameterConfiguration
 	if pcfg == nil {
 		// Add empty structures.
 		// renderer access internal structures that need to be initialized,
@@ -76,6 +182,10 @@ func NewRenderer(wkl *commonv1alpha1.Workload, resolver resolver.Resolver, cmr c
 		r.global = *pcfg.Global
 	}
 
+	if err := validateContainerSelectors(pcfg, containers); err != nil {
+		return nil, err
+	}
+
 	add, err := newAddRenderer(pcfg.Add, cmr)
 	if err != nil {
 		return nil, err
@@ -94,6 +204,13 @@ func NewRenderer(wkl *commonv1alpha1.Workload, resolver resolver.Resolver, cmr c
 }
 
 func (r *renderer) Render(ctx context.Context, obj reconciler.Object) error {
+	if r.security != nil {
+		obj.SetSecuritySettings(r.security)
+	}
+	if len(r.initContainers) != 0 || len(r.sidecars) != 0 {
+		obj.SetAdditionalContainers(r.initContainers, r.sidecars)
+	}
+
 	uobj, ok := obj.AsKubeObject().(*unstructured.Unstructured)
 	if !ok {
 		return fmt.Errorf("could not parse object into unstructured: %s", obj.GetName())
@@ -102,7 +219,13 @@ func (r *renderer) Render(ctx context.Context, obj reconciler.Object) error {
 	// not having a spec is possible, just return without error
 	uobjRoot, ok := uobj.Object[rootObject]
 	if !ok {
-		return nil
+		if err := r.renderContainer(obj, nil); err != nil {
+			return err
+		}
+		if err := r.renderScheduling(obj, nil); err != nil {
+			return err
+		}
+		return r.renderImage(obj, nil)
 	}
 
 	root, ok := uobjRoot.(map[string]interface{})
@@ -110,6 +233,21 @@ func (r *renderer) Render(ctx context.Context, obj reconciler.Object) error {
 		return fmt.Errorf("object %q is expected to be a map[string]interface{}", rootObject)
 	}
 
+	if len(r.fieldMappings) != 0 {
+		root = applyFieldMappings(root, r.fieldMappings)
+	}
+
+	if err := r.renderContainer(obj, root); err != nil {
+		return err
+	}
+	if err := r.renderScheduling(obj, root); err != nil {
+		return err
+	}
+	if err := r.renderImage(obj, root); err != nil {
+		return err
+	}
+	root = r.skipWorkloadPaths(root)
+
 	// do a first pass of the unstructured and turn it into an
 	// structure that can be used to apply the registered configuration.
 	parsedFields := r.restructureIntoParsedFields(root, []string{rootObject})
@@ -172,6 +310,13 @@ func (r *renderer) renderParsedFields(ctx context.Context, obj reconciler.Object
 		obj.AddEnvVar(path, evs[path])
 	}
 
+	for path, cs := range r.add.containersByPath {
+		obj.SetEnvVarContainers(path, cs)
+	}
+	for path, cs := range r.spec.evContainersByPath {
+		obj.SetEnvVarContainers(path, cs)
+	}
+
 	// Iterate all elements in the user object parsed fields structure.
 	for _, k := range fieldNames {
 
@@ -478,9 +623,10 @@ func (pfs parseFields) secretReferenceToEnvVarSource(ss *corev1.SecretKeySelecto
 
 func (pfs parseFields) volumeReferenceToVolume(v *commonv1alpha1.FromSpecToVolume) (*commonv1alpha1.FromSpecToVolume, error) {
 	fsv := &commonv1alpha1.FromSpecToVolume{
-		Name:      v.Name,
-		Path:      v.Path,
-		MountPath: v.MountPath,
+		Name:       v.Name,
+		Path:       v.Path,
+		MountPath:  v.MountPath,
+		Containers: v.Containers,
 	}
 
 	switch {
@@ -613,9 +759,13 @@ func (r *renderer) renderStatus(obj reconciler.Object) error {
 	for i := range r.addStatus {
 		sae := r.addStatus[i]
 
+		// Values from children are rendered after reconciling them.
+		if sae.ValueFrom.Child != nil {
+			continue
+		}
+
 		path := strings.Split(sae.Path, ".")
 
-		// Only value from path is allowed for now.
 		ev := obj.GetEnvVarAtPath(sae.ValueFrom.Path)
 		if ev == nil {
 			continue
@@ -763,3 +913,296 @@ func (r *renderer) defaultRendering(pf *parsedField, evName string) (*corev1.Env
 
 	return ev, nil
 }
+
+// applyFieldMappings returns a copy of the spec where elements at the
+// mapping source are moved to the target, when the target does not exist.
+func applyFieldMappings(root map[string]interface{}, mappings []commonv1alpha1.FieldMapping) map[string]interface{} {
+	spec := runtime.DeepCopyJSON(root)
+	for _, fm := range mappings {
+		// Paths are validated to start with the root object.
+		from := strings.Split(fm.From, ".")[1:]
+		to := strings.Split(fm.To, ".")[1:]
+
+		v, ok, err := unstructured.NestedFieldNoCopy(spec, from...)
+		if err != nil || !ok {
+			continue
+		}
+
+		if _, ok, _ := unstructured.NestedFieldNoCopy(spec, to...); ok {
+			continue
+		}
+
+		if err := unstructured.SetNestedField(spec, v, to...); err != nil {
+			continue
+		}
+		unstructured.RemoveNestedField(spec, from...)
+	}
+
+	return spec
+}
+
+// renderContainer sets the container settings at the object, overriding
+// them with the instance spec elements when configured.
+func (r *renderer) renderContainer(obj reconciler.Object, root map[string]interface{}) error {
+	if r.container == nil {
+		return nil
+	}
+
+	cs := r.container.ContainerSettings.DeepCopy()
+	if fs := r.container.FromSpec; fs != nil && root != nil {
+		resources := &corev1.ResourceRequirements{}
+		if ok, err := fromSpecPath(root, fs.Resources, resources); err != nil {
+			return err
+		} else if ok {
+			cs.Resources = resources
+		}
+
+		for _, fp := range []struct {
+			path  string
+			probe **corev1.Probe
+		}{
+			{fs.LivenessProbe, &cs.LivenessProbe},
+			{fs.ReadinessProbe, &cs.ReadinessProbe},
+			{fs.StartupProbe, &cs.StartupProbe},
+		} {
+			p := &corev1.Probe{}
+			if ok, err := fromSpecPath(root, fp.path, p); err != nil {
+				return err
+			} else if ok {
+				*fp.probe = p
+			}
+		}
+	}
+
+	obj.SetContainerSettings(cs)
+	return nil
+}
+
+// fromSpecPath decodes the element at the spec path into the target.
+// Returns false when the path is empty or the element does not exist.
+func fromSpecPath(root map[string]interface{}, path string, target interface{}) (bool, error) {
+	if path == "" {
+		return false, nil
+	}
+
+	// Paths are validated to start with the root object.
+	v, ok, err := unstructured.NestedFieldNoCopy(root, strings.Split(path, ".")[1:]...)
+	if err != nil || !ok {
+		return false, nil
+	}
+
+	// Check the element type matches the target before decoding
+	// to return a meaningful error.
+	switch reflect.TypeOf(target).Elem().Kind() {
+	case reflect.Slice:
+		if _, ok := v.([]interface{}); !ok {
+			return false, fmt.Errorf("element at %s is expected to be an array", path)
+		}
+	case reflect.String:
+		if _, ok := v.(string); !ok {
+			return false, fmt.Errorf("element at %s is expected to be a string", path)
+		}
+	default:
+		if _, ok := v.(map[string]interface{}); !ok {
+			return false, fmt.Errorf("element at %s is expected to be an object", path)
+		}
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return false, fmt.Errorf("could not read element at %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(b, target); err != nil {
+		return false, fmt.Errorf("could not parse element at %s: %w", path, err)
+	}
+
+	return true, nil
+}
+
+// renderScheduling sets the pods scheduling settings at the object,
+// overriding them with the instance spec elements when configured.
+func (r *renderer) renderScheduling(obj reconciler.Object, root map[string]interface{}) error {
+	if r.scheduling == nil {
+		return nil
+	}
+
+	ss := r.scheduling.SchedulingSettings.DeepCopy()
+	if fs := r.scheduling.FromSpec; fs != nil && root != nil {
+		nodeSelector := map[string]string{}
+		if ok, err := fromSpecPath(root, fs.NodeSelector, &nodeSelector); err != nil {
+			return err
+		} else if ok {
+			ss.NodeSelector = nodeSelector
+		}
+
+		affinity := &corev1.Affinity{}
+		if ok, err := fromSpecPath(root, fs.Affinity, affinity); err != nil {
+			return err
+		} else if ok {
+			ss.Affinity = affinity
+		}
+
+		tolerations := []corev1.Toleration{}
+		if ok, err := fromSpecPath(root, fs.Tolerations, &tolerations); err != nil {
+			return err
+		} else if ok {
+			ss.Tolerations = tolerations
+		}
+
+		constraints := []corev1.TopologySpreadConstraint{}
+		if ok, err := fromSpecPath(root, fs.TopologySpreadConstraints, &constraints); err != nil {
+			return err
+		} else if ok {
+			ss.TopologySpreadConstraints = constraints
+		}
+
+		if _, err := fromSpecPath(root, fs.PriorityClassName, &ss.PriorityClassName); err != nil {
+			return err
+		}
+	}
+
+	obj.SetSchedulingSettings(ss)
+	return nil
+}
+
+// schedulingPaths returns the instance spec paths used for scheduling.
+func schedulingPaths(fs *commonv1alpha1.SchedulingFromSpec) []string {
+	return []string{fs.NodeSelector, fs.Affinity, fs.Tolerations, fs.TopologySpreadConstraints, fs.PriorityClassName}
+}
+
+// renderImage sets the image and pull settings at the object, overriding
+// the image with the instance spec element when configured.
+func (r *renderer) renderImage(obj reconciler.Object, root map[string]interface{}) error {
+	image := r.image.Image()
+
+	if fs := r.image.FromSpec; fs != nil && root != nil {
+		// Paths are validated to start with the root object.
+		v, ok, err := unstructured.NestedFieldNoCopy(root, strings.Split(fs.Path, ".")[1:]...)
+		if err == nil && ok {
+			override, ok := v.(string)
+			if !ok {
+				return fmt.Errorf("element at %s is expected to be a string", fs.Path)
+			}
+
+			if !allowedRegistry(override, fs.AllowedRegistries) {
+				return fmt.Errorf("image %q at %s is not from an allowed registry", override, fs.Path)
+			}
+			image = override
+		}
+	}
+
+	obj.SetImage(image, r.image.PullPolicy)
+
+	for _, ps := range r.image.PullSecrets {
+		if !ps.CopyFromScobyNamespace {
+			obj.AddImagePullSecret(ps.Name, "")
+			continue
+		}
+
+		// Copies are named after the instance to avoid collisions
+		// between registrations at the same namespace.
+		obj.AddImagePullSecret(kmeta.ChildName(obj.GetName()+"-", ps.Name), ps.Name)
+	}
+
+	return nil
+}
+
+// allowedRegistry returns true when the image belongs to any of
+// the registries.
+func allowedRegistry(image string, registries []string) bool {
+	for _, reg := range registries {
+		if strings.HasPrefix(image, strings.TrimSuffix(reg, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// additionalContainerNames validates the workload init containers and
+// sidecars, returning the set of container names at the workload pods.
+func additionalContainerNames(wkl *commonv1alpha1.Workload) (map[string]struct{}, error) {
+	names := map[string]struct{}{
+		reconciler.DefaultContainerName: {},
+	}
+
+	for _, cs := range [][]commonv1alpha1.AdditionalContainer{wkl.InitContainers, wkl.Sidecars} {
+		for i := range cs {
+			if _, ok := names[cs[i].Name]; ok {
+				return nil, fmt.Errorf("container name %q is duplicated or reserved", cs[i].Name)
+			}
+			names[cs[i].Name] = struct{}{}
+		}
+	}
+
+	for i := range wkl.InitContainers {
+		ic := &wkl.InitContainers[i]
+		if ic.LivenessProbe != nil || ic.ReadinessProbe != nil || ic.StartupProbe != nil || ic.Lifecycle != nil {
+			return nil, fmt.Errorf("init container %q cannot declare probes or lifecycle", ic.Name)
+		}
+	}
+
+	return names, nil
+}
+
+// validateContainerSelectors checks that rendering rules only select
+// existing containers.
+func validateContainerSelectors(pcfg *commonv1alpha1.ParameterConfiguration, names map[string]struct{}) error {
+	selectors := []commonv1alpha1.ContainerSelector{}
+	if pcfg.Add != nil {
+		for i := range pcfg.Add.ToEnv {
+			selectors = append(selectors, pcfg.Add.ToEnv[i].Containers)
+		}
+	}
+	if pcfg.FromSpec != nil {
+		for i := range pcfg.FromSpec.ToEnv {
+			selectors = append(selectors, pcfg.FromSpec.ToEnv[i].Containers)
+		}
+		for i := range pcfg.FromSpec.ToVolume {
+			selectors = append(selectors, pcfg.FromSpec.ToVolume[i].Containers)
+		}
+	}
+
+	for _, cs := range selectors {
+		for _, c := range cs {
+			if _, ok := names[c]; !ok {
+				return fmt.Errorf("rendering rule selects unknown container %q", c)
+			}
+		}
+	}
+
+	return nil
+}
+
+// skipWorkloadPaths returns a copy of the spec without the elements used
+// for container, scheduling, image and autoscaling settings, which are not
+// rendered as parameters.
+func (r *renderer) skipWorkloadPaths(root map[string]interface{}) map[string]interface{} {
+	paths := []string{}
+	if r.container != nil && r.container.FromSpec != nil {
+		fs := r.container.FromSpec
+		paths = append(paths, fs.Resources, fs.LivenessProbe, fs.ReadinessProbe, fs.StartupProbe)
+	}
+	if r.scheduling != nil && r.scheduling.FromSpec != nil {
+		paths = append(paths, schedulingPaths(r.scheduling.FromSpec)...)
+	}
+	if r.image.FromSpec != nil {
+		paths = append(paths, r.image.FromSpec.Path)
+	}
+	if r.autoscaling != nil {
+		paths = append(paths, r.autoscaling.MinReplicas, r.autoscaling.MaxReplicas)
+	}
+
+	if len(paths) == 0 {
+		return root
+	}
+
+	spec := runtime.DeepCopyJSON(root)
+	for _, p := range paths {
+		if p != "" {
+			unstructured.RemoveNestedField(spec, strings.Split(p, ".")[1:]...)
+		}
+	}
+
+	return spec
+}
@@ -13,6 +13,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/yaml"
@@ -598,3 +600,733 @@ fromSpec:
 		})
 	}
 }
+
+func TestRenderedContainerVersionRules(t *testing.T) {
+	crdv := basecrd.CRDPrioritizedVersion(ReadCRD(kuardCRD))
+
+	// Instance created using a previous version where variable1
+	// was named oldVariable1.
+	instance := `
+apiVersion: extensions.triggermesh.io/v1
+kind: Kuard
+metadata:
+  name: my-kuard-extension
+spec:
+  oldVariable1: value 1
+  variable2: value 2
+`
+
+	workload := `
+parameterConfiguration:
+  fromSpec:
+    skip:
+    - path: spec.variable2
+versionConfiguration:
+  rules:
+  - version: v1
+    fieldMappings:
+    - from: spec.oldVariable1
+      to: spec.variable1
+    parameterConfiguration:
+      global:
+        defaultPrefix: V1_
+`
+
+	testCases := map[string]struct {
+		version      string
+		expectedEnvs []corev1.EnvVar
+	}{
+		"no version": {
+			expectedEnvs: []corev1.EnvVar{
+				{Name: "OLDVARIABLE1", Value: "value 1"},
+			},
+		},
+		"version with rules": {
+			version: "v1",
+			expectedEnvs: []corev1.EnvVar{
+				{Name: "V1_VARIABLE1", Value: "value 1"},
+				{Name: "V1_VARIABLE2", Value: "value 2"},
+			},
+		},
+		"version without rules": {
+			version: "v1beta1",
+			expectedEnvs: []corev1.EnvVar{
+				{Name: "OLDVARIABLE1", Value: "value 1"},
+			},
+		},
+	}
+
+	logr := tlogr.NewTestLogger(t)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			wkl := &commonv1alpha1.Workload{}
+			require.NoError(t, yaml.Unmarshal([]byte(workload), wkl))
+
+			client := fake.NewClientBuilder().Build()
+			cmr := configmap.NewNamespacedReader(tScobyNamespace, client)
+
+			r, err := NewRenderer(wkl, resolver.New(client), cmr, WithCRDVersion(tc.version))
+			require.NoError(t, err, "error creating renderer")
+
+			smf := basestatus.NewStatusManagerFactory(crdv, "", nil, logr)
+			mgr := baseobject.NewManager(gvk, r, smf)
+
+			obj := mgr.NewObject()
+			u := obj.AsKubeObject().(*unstructured.Unstructured)
+			require.NoError(t, yaml.Unmarshal([]byte(instance), u))
+
+			require.NoError(t, r.Render(context.Background(), obj))
+
+			c := resources.NewContainer("test-name", "test-image", obj.AsContainerOptions()...)
+			assert.Equal(t, tc.expectedEnvs, c.Env)
+
+			// The instance is not modified by field mappings.
+			_, ok := u.Object["spec"].(map[string]interface{})["oldVariable1"]
+			assert.True(t, ok)
+		})
+	}
+}
+
+func TestRenderedContainerSettings(t *testing.T) {
+	crdv := basecrd.CRDPrioritizedVersion(ReadCRD(kuardCRD))
+
+	workload := `
+container:
+  resources:
+    requests:
+      cpu: 100m
+  ports:
+  - name: http
+    containerPort: 8080
+  readinessProbe:
+    httpGet:
+      path: /ready
+      port: http
+  fromSpec:
+    resources: spec.resources
+`
+
+	testCases := map[string]struct {
+		instance string
+
+		expectedResources corev1.ResourceRequirements
+		expectedEnvs      []corev1.EnvVar
+		expectedError     string
+	}{
+		"registration settings": {
+			instance: `
+apiVersion: extensions.triggermesh.io/v1
+kind: Kuard
+metadata:
+  name: my-kuard-extension
+spec:
+  variable1: value 1
+`,
+			expectedResources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+			},
+			expectedEnvs: []corev1.EnvVar{
+				{Name: "VARIABLE1", Value: "value 1"},
+			},
+		},
+		"instance overrides": {
+			instance: `
+apiVersion: extensions.triggermesh.io/v1
+kind: Kuard
+metadata:
+  name: my-kuard-extension
+spec:
+  variable1: value 1
+  resources:
+    limits:
+      memory: 64Mi
+`,
+			expectedResources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+			},
+			expectedEnvs: []corev1.EnvVar{
+				{Name: "VARIABLE1", Value: "value 1"},
+			},
+		},
+		"instance element is not an object": {
+			instance: `
+apiVersion: extensions.triggermesh.io/v1
+kind: Kuard
+metadata:
+  name: my-kuard-extension
+spec:
+  resources: large
+`,
+			expectedError: "element at spec.resources is expected to be an object",
+		},
+	}
+
+	logr := tlogr.NewTestLogger(t)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			wkl := &commonv1alpha1.Workload{}
+			require.NoError(t, yaml.Unmarshal([]byte(workload), wkl))
+
+			client := fake.NewClientBuilder().Build()
+			cmr := configmap.NewNamespacedReader(tScobyNamespace, client)
+
+			r, err := NewRenderer(wkl, resolver.New(client), cmr)
+			require.NoError(t, err, "error creating renderer")
+
+			smf := basestatus.NewStatusManagerFactory(crdv, "", nil, logr)
+			mgr := baseobject.NewManager(gvk, r, smf)
+
+			obj := mgr.NewObject()
+			u := obj.AsKubeObject().(*unstructured.Unstructured)
+			require.NoError(t, yaml.Unmarshal([]byte(tc.instance), u))
+
+			err = r.Render(context.Background(), obj)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			c := resources.NewContainer("test-name", "test-image", obj.AsContainerOptions()...)
+			assert.Equal(t, tc.expectedEnvs, c.Env)
+			assert.True(t, equality.Semantic.DeepEqual(tc.expectedResources, c.Resources),
+				"unexpected resources %v", c.Resources)
+			assert.Equal(t, []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}}, c.Ports)
+			require.NotNil(t, c.ReadinessProbe)
+			assert.Equal(t, "/ready", c.ReadinessProbe.HTTPGet.Path)
+		})
+	}
+}
+
+func TestRenderedContainerImage(t *testing.T) {
+	crdv := basecrd.CRDPrioritizedVersion(ReadCRD(kuardCRD))
+
+	workload := `
+fromImage:
+  repo: gcr.io/triggermesh/kuard
+  tag: v1
+  pullPolicy: Always
+  pullSecrets:
+  - name: local-registry
+  - name: shared-registry
+    copyFromScobyNamespace: true
+  fromSpec:
+    path: spec.image
+    allowedRegistries:
+    - gcr.io/triggermesh/
+    - quay.io
+`
+
+	testCases := map[string]struct {
+		instance string
+
+		expectedImage string
+		expectedEnvs  []corev1.EnvVar
+		expectedError string
+	}{
+		"registration image": {
+			instance: `
+apiVersion: extensions.triggermesh.io/v1
+kind: Kuard
+metadata:
+  name: my-kuard-extension
+spec:
+  variable1: value 1
+`,
+			expectedImage: "gcr.io/triggermesh/kuard:v1",
+			expectedEnvs: []corev1.EnvVar{
+				{Name: "VARIABLE1", Value: "value 1"},
+			},
+		},
+		"instance override": {
+			instance: `
+apiVersion: extensions.triggermesh.io/v1
+kind: Kuard
+metadata:
+  name: my-kuard-extension
+spec:
+  variable1: value 1
+  image: quay.io/kuard/kuard:v2
+`,
+			expectedImage: "quay.io/kuard/kuard:v2",
+			expectedEnvs: []corev1.EnvVar{
+				{Name: "VARIABLE1", Value: "value 1"},
+			},
+		},
+		"instance override from not allowed registry": {
+			instance: `
+apiVersion: extensions.triggermesh.io/v1
+kind: Kuard
+metadata:
+  name: my-kuard-extension
+spec:
+  image: gcr.io/triggermesh-fake/kuard:v2
+`,
+			expectedError: `image "gcr.io/triggermesh-fake/kuard:v2" at spec.image is not from an allowed registry`,
+		},
+	}
+
+	logr := tlogr.NewTestLogger(t)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			wkl := &commonv1alpha1.Workload{}
+			require.NoError(t, yaml.Unmarshal([]byte(workload), wkl))
+
+			client := fake.NewClientBuilder().Build()
+			cmr := configmap.NewNamespacedReader(tScobyNamespace, client)
+
+			r, err := NewRenderer(wkl, resolver.New(client), cmr)
+			require.NoError(t, err, "error creating renderer")
+
+			smf := basestatus.NewStatusManagerFactory(crdv, "", nil, logr)
+			mgr := baseobject.NewManager(gvk, r, smf)
+
+			obj := mgr.NewObject()
+			u := obj.AsKubeObject().(*unstructured.Unstructured)
+			require.NoError(t, yaml.Unmarshal([]byte(tc.instance), u))
+
+			err = r.Render(context.Background(), obj)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			c := resources.NewContainer("test-name", wkl.FromImage.Image(), obj.AsContainerOptions()...)
+			assert.Equal(t, tc.expectedImage, c.Image)
+			assert.Equal(t, corev1.PullAlways, c.ImagePullPolicy)
+			assert.Equal(t, tc.expectedEnvs, c.Env)
+
+			ps := resources.NewPodSpec(obj.AsPodSpecOptions()...)
+			assert.Equal(t, []corev1.LocalObjectReference{
+				{Name: "local-registry"},
+				{Name: "my-kuard-extension-shared-registry"},
+			}, ps.ImagePullSecrets)
+			assert.Equal(t, map[string]string{
+				"my-kuard-extension-shared-registry": "shared-registry",
+			}, obj.GetImagePullSecretCopies())
+		})
+	}
+}
+
+func TestRenderedScheduling(t *testing.T) {
+	crdv := basecrd.CRDPrioritizedVersion(ReadCRD(kuardCRD))
+
+	workload := `
+scheduling:
+  nodeSelector:
+    disktype: ssd
+  tolerations:
+  - key: dedicated
+    operator: Equal
+    value: adapters
+    effect: NoSchedule
+  topologySpreadConstraints:
+  - maxSkew: 1
+    topologyKey: topology.kubernetes.io/zone
+    whenUnsatisfiable: ScheduleAnyway
+  priorityClassName: standard
+  fromSpec:
+    nodeSelector: spec.nodeSelector
+    tolerations: spec.tolerations
+    priorityClassName: spec.priority
+`
+
+	testCases := map[string]struct {
+		instance string
+
+		expectedNodeSelector map[string]string
+		expectedTolerations  int
+		expectedPriority     string
+		expectedEnvs         []corev1.EnvVar
+		expectedError        string
+	}{
+		"registration settings": {
+			instance: `
+apiVersion: extensions.triggermesh.io/v1
+kind: Kuard
+metadata:
+  name: my-kuard-extension
+spec:
+  variable1: value 1
+`,
+			expectedNodeSelector: map[string]string{"disktype": "ssd"},
+			expectedTolerations:  1,
+			expectedPriority:     "standard",
+			expectedEnvs: []corev1.EnvVar{
+				{Name: "VARIABLE1", Value: "value 1"},
+			},
+		},
+		"instance overrides": {
+			instance: `
+apiVersion: extensions.triggermesh.io/v1
+kind: Kuard
+metadata:
+  name: my-kuard-extension
+spec:
+  variable1: value 1
+  nodeSelector:
+    zone: eu-west-1a
+  tolerations: []
+  priority: critical
+`,
+			expectedNodeSelector: map[string]string{"zone": "eu-west-1a"},
+			expectedTolerations:  0,
+			expectedPriority:     "critical",
+			expectedEnvs: []corev1.EnvVar{
+				{Name: "VARIABLE1", Value: "value 1"},
+			},
+		},
+		"instance element is not an array": {
+			instance: `
+apiVersion: extensions.triggermesh.io/v1
+kind: Kuard
+metadata:
+  name: my-kuard-extension
+spec:
+  tolerations:
+    key: dedicated
+`,
+			expectedError: "element at spec.tolerations is expected to be an array",
+		},
+	}
+
+	logr := tlogr.NewTestLogger(t)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			wkl := &commonv1alpha1.Workload{}
+			require.NoError(t, yaml.Unmarshal([]byte(workload), wkl))
+
+			client := fake.NewClientBuilder().Build()
+			cmr := configmap.NewNamespacedReader(tScobyNamespace, client)
+
+			r, err := NewRenderer(wkl, resolver.New(client), cmr)
+			require.NoError(t, err, "error creating renderer")
+
+			smf := basestatus.NewStatusManagerFactory(crdv, "", nil, logr)
+			mgr := baseobject.NewManager(gvk, r, smf)
+
+			obj := mgr.NewObject()
+			u := obj.AsKubeObject().(*unstructured.Unstructured)
+			require.NoError(t, yaml.Unmarshal([]byte(tc.instance), u))
+
+			err = r.Render(context.Background(), obj)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			ps := resources.NewPodSpec(obj.AsPodSpecOptions()...)
+			assert.Equal(t, tc.expectedNodeSelector, ps.NodeSelector)
+			assert.Len(t, ps.Tolerations, tc.expectedTolerations)
+			assert.Equal(t, tc.expectedPriority, ps.PriorityClassName)
+			require.Len(t, ps.TopologySpreadConstraints, 1)
+			assert.Equal(t, "topology.kubernetes.io/zone", ps.TopologySpreadConstraints[0].TopologyKey)
+
+			c := resources.NewContainer("test-name", "test-image", obj.AsContainerOptions()...)
+			assert.Equal(t, tc.expectedEnvs, c.Env)
+		})
+	}
+}
+
+func TestRenderedSecurityContext(t *testing.T) {
+	crdv := basecrd.CRDPrioritizedVersion(ReadCRD(kuardCRD))
+
+	instance := `
+apiVersion: extensions.triggermesh.io/v1
+kind: Kuard
+metadata:
+  name: my-kuard-extension
+spec:
+  variable1: value 1
+`
+
+	testCases := map[string]struct {
+		workload string
+
+		expectedReadOnly bool
+		expectedPod      bool
+		expectedTmp      bool
+	}{
+		"restricted defaults": {
+			workload:         `{}`,
+			expectedReadOnly: true,
+		},
+		"custom contexts with writable tmp": {
+			workload: `
+securityContext:
+  pod:
+    fsGroup: 1000
+  container:
+    readOnlyRootFilesystem: false
+  writableTmp: true
+`,
+			expectedReadOnly: false,
+			expectedPod:      true,
+			expectedTmp:      true,
+		},
+	}
+
+	logr := tlogr.NewTestLogger(t)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			wkl := &commonv1alpha1.Workload{}
+			require.NoError(t, yaml.Unmarshal([]byte(tc.workload), wkl))
+
+			client := fake.NewClientBuilder().Build()
+			cmr := configmap.NewNamespacedReader(tScobyNamespace, client)
+
+			r, err := NewRenderer(wkl, resolver.New(client), cmr)
+			require.NoError(t, err, "error creating renderer")
+
+			smf := basestatus.NewStatusManagerFactory(crdv, "", nil, logr)
+			mgr := baseobject.NewManager(gvk, r, smf)
+
+			obj := mgr.NewObject()
+			u := obj.AsKubeObject().(*unstructured.Unstructured)
+			require.NoError(t, yaml.Unmarshal([]byte(instance), u))
+			require.NoError(t, r.Render(context.Background(), obj))
+
+			c := resources.NewContainer("test-name", "test-image", obj.AsContainerOptions()...)
+			require.NotNil(t, c.SecurityContext)
+			require.NotNil(t, c.SecurityContext.ReadOnlyRootFilesystem)
+			assert.Equal(t, tc.expectedReadOnly, *c.SecurityContext.ReadOnlyRootFilesystem)
+
+			ps := resources.NewPodSpec(obj.AsPodSpecOptions()...)
+			assert.Equal(t, tc.expectedPod, ps.SecurityContext != nil)
+
+			if !tc.expectedTmp {
+				assert.Empty(t, ps.Volumes)
+				assert.Empty(t, c.VolumeMounts)
+
+				// Defaults comply with the restricted Pod Security Standard.
+				sc := c.SecurityContext
+				assert.False(t, *sc.AllowPrivilegeEscalation)
+				assert.True(t, *sc.RunAsNonRoot)
+				assert.Equal(t, []corev1.Capability{"ALL"}, sc.Capabilities.Drop)
+				assert.Equal(t, corev1.SeccompProfileTypeRuntimeDefault, sc.SeccompProfile.Type)
+				return
+			}
+
+			require.Len(t, ps.Volumes, 1)
+			assert.NotNil(t, ps.Volumes[0].EmptyDir)
+			assert.Equal(t, []corev1.VolumeMount{{Name: ps.Volumes[0].Name, MountPath: "/tmp"}}, c.VolumeMounts)
+		})
+	}
+}
+
+func TestRenderedAdditionalContainers(t *testing.T) {
+	crdv := basecrd.CRDPrioritizedVersion(ReadCRD(kuardCRD))
+
+	instance := `
+apiVersion: extensions.triggermesh.io/v1
+kind: Kuard
+metadata:
+  name: my-kuard-extension
+spec:
+  variable1: value 1
+  variable2: value 2
+`
+
+	workload := `
+initContainers:
+- name: certs
+  image: registry.example.com/certs:v1
+  command: ["fetch-certs"]
+sidecars:
+- name: metrics
+  image: registry.example.com/exporter:v1
+  shareRendered: true
+- name: proxy
+  image: registry.example.com/proxy:v1
+parameterConfiguration:
+  fromSpec:
+    toEnv:
+    - path: spec.variable2
+      containers: ["adapter", "proxy"]
+`
+
+	testCases := map[string]struct {
+		workload string
+
+		expectedErr string
+	}{
+		"init containers and sidecars": {
+			workload: workload,
+		},
+		"reserved name": {
+			workload: `
+sidecars:
+- name: adapter
+  image: registry.example.com/exporter:v1
+`,
+			expectedErr: `container name "adapter" is duplicated or reserved`,
+		},
+		"init container probes": {
+			workload: `
+initContainers:
+- name: certs
+  image: registry.example.com/certs:v1
+  livenessProbe:
+    tcpSocket:
+      port: 8080
+`,
+			expectedErr: `init container "certs" cannot declare probes or lifecycle`,
+		},
+		"unknown selected container": {
+			workload: `
+parameterConfiguration:
+  fromSpec:
+    toEnv:
+    - path: spec.variable2
+      containers: ["proxy"]
+`,
+			expectedErr: `rendering rule selects unknown container "proxy"`,
+		},
+	}
+
+	logr := tlogr.NewTestLogger(t)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			wkl := &commonv1alpha1.Workload{}
+			require.NoError(t, yaml.Unmarshal([]byte(tc.workload), wkl))
+
+			client := fake.NewClientBuilder().Build()
+			cmr := configmap.NewNamespacedReader(tScobyNamespace, client)
+
+			r, err := NewRenderer(wkl, resolver.New(client), cmr)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err, "error creating renderer")
+
+			smf := basestatus.NewStatusManagerFactory(crdv, "", nil, logr)
+			mgr := baseobject.NewManager(gvk, r, smf)
+
+			obj := mgr.NewObject()
+			u := obj.AsKubeObject().(*unstructured.Unstructured)
+			require.NoError(t, yaml.Unmarshal([]byte(instance), u))
+			require.NoError(t, r.Render(context.Background(), obj))
+
+			envNames := func(c *corev1.Container) []string {
+				names := []string{}
+				for _, ev := range c.Env {
+					names = append(names, ev.Name)
+				}
+				return names
+			}
+
+			c := resources.NewContainer("adapter", "test-image", obj.AsContainerOptions()...)
+			assert.Equal(t, []string{"VARIABLE1", "VARIABLE2"}, envNames(c))
+
+			ps := resources.NewPodSpec(obj.AsPodSpecOptions()...)
+
+			require.Len(t, ps.InitContainers, 1)
+			ic := ps.InitContainers[0]
+			assert.Equal(t, "certs", ic.Name)
+			assert.Equal(t, []string{"fetch-certs"}, ic.Command)
+			assert.Empty(t, ic.Env)
+			// Additional containers default to the workload security context.
+			assert.Equal(t, c.SecurityContext, ic.SecurityContext)
+
+			require.Len(t, ps.Containers, 2)
+			assert.Equal(t, "metrics", ps.Containers[0].Name)
+			assert.Equal(t, []string{"VARIABLE1"}, envNames(&ps.Containers[0]))
+			assert.Equal(t, "proxy", ps.Containers[1].Name)
+			assert.Equal(t, []string{"VARIABLE2"}, envNames(&ps.Containers[1]))
+		})
+	}
+}
+
+func TestRenderedAutoscalingPaths(t *testing.T) {
+	crdv := basecrd.CRDPrioritizedVersion(ReadCRD(kuardCRD))
+
+	instance := `
+apiVersion: extensions.triggermesh.io/v1
+kind: Kuard
+metadata:
+  name: my-kuard-extension
+spec:
+  variable1: value 1
+  scaling:
+    min: 2
+    max: 5
+`
+
+	testCases := map[string]struct {
+		workload string
+
+		expectedEnv []string
+		expectedErr string
+	}{
+		"override paths are not rendered": {
+			workload: `
+formFactor:
+  deployment:
+    autoscaling:
+      maxReplicas: 3
+      fromSpec:
+        minReplicas: spec.scaling.min
+        maxReplicas: spec.scaling.max
+`,
+			expectedEnv: []string{"VARIABLE1"},
+		},
+		"no autoscaling": {
+			workload:    `{}`,
+			expectedEnv: []string{"SCALING_MAX", "SCALING_MIN", "VARIABLE1"},
+		},
+		"path outside spec": {
+			workload: `
+formFactor:
+  deployment:
+    autoscaling:
+      maxReplicas: 3
+      fromSpec:
+        maxReplicas: status.max
+`,
+			expectedErr: `autoscaling paths must start with "spec": status.max`,
+		},
+	}
+
+	logr := tlogr.NewTestLogger(t)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			wkl := &commonv1alpha1.Workload{}
+			require.NoError(t, yaml.Unmarshal([]byte(tc.workload), wkl))
+
+			client := fake.NewClientBuilder().Build()
+			cmr := configmap.NewNamespacedReader(tScobyNamespace, client)
+
+			r, err := NewRenderer(wkl, resolver.New(client), cmr)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err, "error creating renderer")
+
+			smf := basestatus.NewStatusManagerFactory(crdv, "", nil, logr)
+			mgr := baseobject.NewManager(gvk, r, smf)
+
+			obj := mgr.NewObject()
+			u := obj.AsKubeObject().(*unstructured.Unstructured)
+			require.NoError(t, yaml.Unmarshal([]byte(instance), u))
+			require.NoError(t, r.Render(context.Background(), obj))
+
+			c := resources.NewContainer("test-name", "test-image", obj.AsContainerOptions()...)
+			env := []string{}
+			for _, ev := range c.Env {
+				env = append(env, ev.Name)
+			}
+			assert.Equal(t, tc.expectedEnv, env)
+		})
+	}
+}
@@ -22,6 +22,7 @@ type specRenderer struct {
 	evConfigMapByPath       map[string]corev1.ConfigMapKeySelector
 	evSecretByPath          map[string]corev1.SecretKeySelector
 	evBuiltInFunctionByPath map[string]commonv1alpha1.BuiltInfunction
+	evContainersByPath      map[string]commonv1alpha1.ContainerSelector
 
 	// Volume mount structured information.
 	volumeByPath map[string]commonv1alpha1.FromSpecToVolume
@@ -37,6 +38,7 @@ func newSpecRenderer(speccfg *commonv1alpha1.FromSpecConfiguration) (*specRender
 		evConfigMapByPath:       make(map[string]corev1.ConfigMapKeySelector),
 		evSecretByPath:          make(map[string]corev1.SecretKeySelector),
 		evBuiltInFunctionByPath: make(map[string]commonv1alpha1.BuiltInfunction),
+		evContainersByPath:      make(map[string]commonv1alpha1.ContainerSelector),
 
 		volumeByPath: make(map[string]commonv1alpha1.FromSpecToVolume),
 	}
@@ -64,6 +66,10 @@ func newSpecRenderer(speccfg *commonv1alpha1.FromSpecConfiguration) (*specRender
 			sr.allByPath[path] = struct{}{}
 		}
 
+		if len(ev.Containers) != 0 {
+			sr.evContainersByPath[path] = ev.Containers
+		}
+
 		if ev.ValueFrom == nil {
 			continue
 		}
@@ -0,0 +1,258 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package base
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/kmeta"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	commonv1alpha1 "github.com/triggermesh/scoby/pkg/apis/common/v1alpha1"
+	"github.com/triggermesh/scoby/pkg/component/reconciler"
+	"github.com/triggermesh/scoby/pkg/utils/resources"
+	"github.com/triggermesh/scoby/pkg/utils/semantic"
+)
+
+// Children map keys for the ServiceAccount and its permissions.
+const (
+	serviceAccountChildKey     = "serviceaccount"
+	roleChildKey               = "role"
+	roleBindingChildKey        = "rolebinding"
+	clusterRoleBindingChildKey = "clusterrolebinding"
+)
+
+// serviceAccountName returns the name of the ServiceAccount for the
+// object, or an empty string when no ServiceAccount is configured.
+func (b *base) serviceAccountName(obj reconciler.Object) string {
+	switch {
+	case b.serviceAccount == nil:
+		return ""
+	case b.serviceAccount.Scope == commonv1alpha1.ServiceAccountScopeNamespace:
+		return kmeta.ChildName(b.registration, "-adapter")
+	}
+
+	return kmeta.ChildName(b.registration+"-", obj.GetName())
+}
+
+// serviceAccountObjects returns the ServiceAccount, Role and RoleBindings
+// for the object indexed by their children key. Nil objects are not
+// desired.
+func (b *base) serviceAccountObjects(obj reconciler.Object) (map[string]*unstructured.Unstructured, error) {
+	name := b.serviceAccountName(obj)
+	ns := obj.GetNamespace()
+
+	metaopts := []resources.MetaOption{
+		resources.MetaAddLabel(resources.AppNameLabel, b.registration),
+		resources.MetaAddLabel(resources.AppPartOfLabel, reconciler.PartOf),
+		resources.MetaAddLabel(resources.AppManagedByLabel, reconciler.ManagedBy),
+	}
+
+	saopts := []resources.MetaOption{}
+	for k, v := range b.serviceAccount.Annotations {
+		saopts = append(saopts, resources.MetaAddAnnotation(k, v))
+	}
+
+	children := map[string]*unstructured.Unstructured{
+		roleChildKey:               nil,
+		roleBindingChildKey:        nil,
+		clusterRoleBindingChildKey: nil,
+	}
+
+	add := func(key string, o interface{}) error {
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(o)
+		if err != nil {
+			return fmt.Errorf("could not convert %s: %w", key, err)
+		}
+		children[key] = &unstructured.Unstructured{Object: u}
+		return nil
+	}
+
+	if err := add(serviceAccountChildKey, resources.NewServiceAccount(ns, name,
+		resources.ServiceAccountWithMetaOptions(append(saopts, metaopts...)...))); err != nil {
+		return nil, err
+	}
+
+	if len(b.serviceAccount.Rules) != 0 {
+		if err := add(roleChildKey, resources.NewRole(ns, name,
+			resources.RoleWithMetaOptions(metaopts...),
+			resources.RoleWithRules(b.serviceAccount.Rules...))); err != nil {
+			return nil, err
+		}
+
+		if err := add(roleBindingChildKey, resources.NewRoleBinding(ns, name, name, name,
+			resources.RoleBindingWithMetaOptions(metaopts...),
+			resources.RoleBindingWithRole(name))); err != nil {
+			return nil, err
+		}
+	}
+
+	if cr := b.serviceAccount.ClusterRole; cr != "" {
+		if err := add(clusterRoleBindingChildKey, resources.NewRoleBinding(ns, kmeta.ChildName(name, "-cluster"), cr, name,
+			resources.RoleBindingWithMetaOptions(metaopts...))); err != nil {
+			return nil, err
+		}
+	}
+
+	return children, nil
+}
+
+// reconcileServiceAccount manages the ServiceAccount and permissions for the
+// object. Objects scoped to the instance are returned to be reconciled as
+// children, while objects shared at the namespace are reconciled here.
+func (b *base) reconcileServiceAccount(ctx context.Context, obj reconciler.Object) (map[string]*unstructured.Unstructured, error) {
+	if b.serviceAccount == nil {
+		return nil, nil
+	}
+
+	objects, err := b.serviceAccountObjects(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.serviceAccount.Scope != commonv1alpha1.ServiceAccountScopeNamespace {
+		return objects, nil
+	}
+
+	name := b.serviceAccountName(obj)
+	for _, k := range []string{serviceAccountChildKey, roleChildKey, roleBindingChildKey, clusterRoleBindingChildKey} {
+		if objects[k] != nil {
+			if err := b.reconcileShared(ctx, obj, objects[k]); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		// Release objects no longer desired.
+		var gvk schema.GroupVersionKind
+		n := name
+		switch k {
+		case roleChildKey:
+			gvk = rbacv1.SchemeGroupVersion.WithKind("Role")
+		case roleBindingChildKey:
+			gvk = rbacv1.SchemeGroupVersion.WithKind("RoleBinding")
+		case clusterRoleBindingChildKey:
+			gvk = rbacv1.SchemeGroupVersion.WithKind("RoleBinding")
+			n = kmeta.ChildName(name, "-cluster")
+		}
+
+		if err := b.releaseShared(ctx, obj, gvk, n); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+// reconcileShared creates or updates an object that is shared by all
+// instances of the registration at the namespace. Each instance is added
+// as a non controller owner, which lets the object be garbage collected
+// once all instances are removed.
+func (b *base) reconcileShared(ctx context.Context, obj reconciler.Object, desired *unstructured.Unstructured) error {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(desired.GroupVersionKind())
+
+	err := b.client.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	switch {
+	case apierrs.IsNotFound(err):
+		if err := controllerutil.SetOwnerReference(obj.AsKubeObject(), desired, b.client.Scheme()); err != nil {
+			return fmt.Errorf("could not set owner for %s %s: %w", desired.GetKind(), desired.GetName(), err)
+		}
+
+		b.log.Info("creating shared object", "object", desired)
+		if err := b.client.Create(ctx, desired); err != nil {
+			b.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeWarning, reconciler.EventReasonChildFailed,
+				"Failed to create %s %s: %v", desired.GetKind(), desired.GetName(), err)
+			return fmt.Errorf("could not create %s %s: %w", desired.GetKind(), desired.GetName(), err)
+		}
+		b.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeNormal, reconciler.EventReasonChildCreated,
+			"Created %s %s", desired.GetKind(), desired.GetName())
+		return nil
+
+	case err != nil:
+		return fmt.Errorf("could not retrieve %s %s: %w", desired.GetKind(), client.ObjectKeyFromObject(desired), err)
+	}
+
+	if l := existing.GetLabels(); l[resources.AppNameLabel] != b.registration ||
+		l[resources.AppManagedByLabel] != reconciler.ManagedBy {
+		return fmt.Errorf("%s %s already exists and is not managed by %s",
+			desired.GetKind(), client.ObjectKeyFromObject(desired), b.registration)
+	}
+
+	// Keep owners from other instances.
+	desired.SetOwnerReferences(existing.GetOwnerReferences())
+	if err := controllerutil.SetOwnerReference(obj.AsKubeObject(), desired, b.client.Scheme()); err != nil {
+		return fmt.Errorf("could not set owner for %s %s: %w", desired.GetKind(), desired.GetName(), err)
+	}
+
+	if semantic.Semantic.DeepEqual(desired, existing) {
+		return nil
+	}
+
+	b.log.Info("existing shared object does not match the expected", "object", desired)
+	desired.SetResourceVersion(existing.GetResourceVersion())
+	if err := b.client.Update(ctx, desired); err != nil {
+		b.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeWarning, reconciler.EventReasonChildFailed,
+			"Failed to update %s %s: %v", desired.GetKind(), desired.GetName(), err)
+		return fmt.Errorf("could not update %s %s: %w", desired.GetKind(), desired.GetName(), err)
+	}
+
+	return nil
+}
+
+// releaseShared removes the object as an owner of a shared object, which is
+// deleted when no owners are left.
+func (b *base) releaseShared(ctx context.Context, obj reconciler.Object, gvk schema.GroupVersionKind, name string) error {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(gvk)
+
+	err := b.client.Get(ctx, client.ObjectKey{Namespace: obj.GetNamespace(), Name: name}, existing)
+	switch {
+	case apierrs.IsNotFound(err):
+		return nil
+	case err != nil:
+		return fmt.Errorf("could not retrieve %s %s: %w", gvk.Kind, name, err)
+	}
+
+	refs := []metav1.OwnerReference{}
+	owned := false
+	for _, ref := range existing.GetOwnerReferences() {
+		if ref.UID == obj.GetUID() {
+			owned = true
+			continue
+		}
+		refs = append(refs, ref)
+	}
+
+	if !owned {
+		return nil
+	}
+
+	if len(refs) == 0 {
+		b.log.Info("deleting shared object no longer desired", "object", client.ObjectKeyFromObject(existing), "kind", gvk.Kind)
+		if err := b.client.Delete(ctx, existing); err != nil && !apierrs.IsNotFound(err) {
+			return fmt.Errorf("could not delete %s %s: %w", gvk.Kind, name, err)
+		}
+		b.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeNormal, reconciler.EventReasonChildDeleted,
+			"Deleted %s %s", gvk.Kind, name)
+		return nil
+	}
+
+	existing.SetOwnerReferences(refs)
+	if err := b.client.Update(ctx, existing); err != nil {
+		return fmt.Errorf("could not release %s %s: %w", gvk.Kind, name, err)
+	}
+
+	return nil
+}
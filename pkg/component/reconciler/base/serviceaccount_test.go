@@ -0,0 +1,154 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package base
+
+import (
+	"context"
+	"testing"
+
+	tlogr "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	commonv1alpha1 "github.com/triggermesh/scoby/pkg/apis/common/v1alpha1"
+	"github.com/triggermesh/scoby/pkg/component/reconciler"
+	"github.com/triggermesh/scoby/pkg/utils/resources"
+)
+
+var tRules = []rbacv1.PolicyRule{{
+	APIGroups: []string{""},
+	Resources: []string{"secrets"},
+	Verbs:     []string{"get"},
+}}
+
+func TestServiceAccountInstanceScope(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewClientBuilder().Build()
+
+	b := &base{
+		registration: tRegistration,
+		serviceAccount: &commonv1alpha1.ServiceAccountConfiguration{
+			Scope:       commonv1alpha1.ServiceAccountScopeInstance,
+			Annotations: map[string]string{"eks.amazonaws.com/role-arn": "arn:aws:iam::123:role/kuard"},
+			Rules:       tRules,
+		},
+		client:   c,
+		recorder: record.NewFakeRecorder(10),
+		log:      tlogr.NewTestLogger(t),
+	}
+	obj := newTestObject(t)
+
+	const saName = tRegistration + "-" + tName
+	assert.Equal(t, saName, b.serviceAccountName(obj))
+
+	children, err := b.reconcileServiceAccount(ctx, obj)
+	require.NoError(t, err)
+	require.NotNil(t, children[serviceAccountChildKey])
+	require.NotNil(t, children[roleChildKey])
+	require.NotNil(t, children[roleBindingChildKey])
+	assert.Nil(t, children[clusterRoleBindingChildKey])
+
+	cr := newChildrenReconciler(tRegistration, c, record.NewFakeRecorder(10), tlogr.NewTestLogger(t))
+	require.NoError(t, cr.Reconcile(ctx, obj, children))
+
+	sa := &corev1.ServiceAccount{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: tNamespace, Name: saName}, sa))
+	assert.Equal(t, "arn:aws:iam::123:role/kuard", sa.Annotations["eks.amazonaws.com/role-arn"])
+
+	rb := &rbacv1.RoleBinding{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: tNamespace, Name: saName}, rb))
+	assert.Equal(t, rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: saName}, rb.RoleRef)
+	assert.Equal(t, saName, rb.Subjects[0].Name)
+
+	obj.SetServiceAccountName(b.serviceAccountName(obj))
+	assert.Equal(t, saName, resources.NewPodSpec(obj.AsPodSpecOptions()...).ServiceAccountName)
+}
+
+func TestServiceAccountNamespaceScope(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewClientBuilder().Build()
+
+	sac := &commonv1alpha1.ServiceAccountConfiguration{
+		Scope:       commonv1alpha1.ServiceAccountScopeNamespace,
+		ClusterRole: "kuard-reader",
+		Rules:       tRules,
+	}
+	b := &base{
+		registration:   tRegistration,
+		serviceAccount: sac,
+		client:         c,
+		recorder:       record.NewFakeRecorder(10),
+		log:            tlogr.NewTestLogger(t),
+	}
+
+	obj1 := newTestObject(t)
+	obj2 := newTestObject(t)
+	obj2.SetName("other-name")
+	obj2.SetUID(types.UID("other-uid"))
+
+	const saName = tRegistration + "-adapter"
+	assert.Equal(t, saName, b.serviceAccountName(obj1))
+	assert.Equal(t, saName, b.serviceAccountName(obj2))
+
+	for _, obj := range []reconciler.Object{obj1, obj2} {
+		children, err := b.reconcileServiceAccount(ctx, obj)
+		require.NoError(t, err)
+		assert.Empty(t, children, "shared objects are not reconciled as children")
+	}
+
+	sa := &corev1.ServiceAccount{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: tNamespace, Name: saName}, sa))
+	require.Len(t, sa.OwnerReferences, 2)
+	assert.Nil(t, sa.OwnerReferences[0].Controller)
+
+	rb := &rbacv1.RoleBinding{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: tNamespace, Name: saName + "-cluster"}, rb))
+	assert.Equal(t, rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "kuard-reader"}, rb.RoleRef)
+
+	// Removing rules releases the generated Role, which is deleted
+	// once no instance owns it.
+	sac.Rules = nil
+
+	_, err := b.reconcileServiceAccount(ctx, obj1)
+	require.NoError(t, err)
+
+	role := &rbacv1.Role{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: tNamespace, Name: saName}, role))
+	assert.Len(t, role.OwnerReferences, 1)
+
+	_, err = b.reconcileServiceAccount(ctx, obj2)
+	require.NoError(t, err)
+
+	err = c.Get(ctx, client.ObjectKey{Namespace: tNamespace, Name: saName}, role)
+	assert.True(t, apierrs.IsNotFound(err), "expected role to be deleted: %v", err)
+}
+
+func TestServiceAccountNotManaged(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewClientBuilder().WithObjects(
+		resources.NewServiceAccount(tNamespace, tRegistration+"-adapter"),
+	).Build()
+
+	b := &base{
+		registration: tRegistration,
+		serviceAccount: &commonv1alpha1.ServiceAccountConfiguration{
+			Scope: commonv1alpha1.ServiceAccountScopeNamespace,
+		},
+		client:   c,
+		recorder: record.NewFakeRecorder(10),
+		log:      tlogr.NewTestLogger(t),
+	}
+
+	_, err := b.reconcileServiceAccount(ctx, newTestObject(t))
+	assert.EqualError(t, err, "ServiceAccount test-ns/kuards-adapter already exists and is not managed by kuards")
+}
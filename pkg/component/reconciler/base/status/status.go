@@ -6,6 +6,7 @@ package status
 import (
 	"errors"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -31,21 +32,134 @@ type realTime struct{}
 
 func (realTime) Now() metav1.Time { return metav1.NewTime(time.Now()) }
 
+// StatusManagerFactoryOption configures the status manager factory.
+type StatusManagerFactoryOption func(*statusManagerFactory)
+
+// WithConditionSeverities sets the severity for condition types. Conditions
+// with a severity other than Error do not vote on happiness.
+func WithConditionSeverities(severities map[string]commonv1alpha1.ConditionSeverity) StatusManagerFactoryOption {
+	return func(smf *statusManagerFactory) {
+		smf.informational = make(map[string]struct{})
+		for t, s := range severities {
+			if s != "" && s != commonv1alpha1.ConditionSeverityError {
+				smf.informational[t] = struct{}{}
+			}
+		}
+	}
+}
+
+// WithAddressURLPath sets the status element, like status.endpoint, that
+// is filled with the address URL. The element must be declared as a string
+// at the CRD.
+func WithAddressURLPath(path string) StatusManagerFactoryOption {
+	return func(smf *statusManagerFactory) {
+		if path != "" {
+			smf.addressPath = path
+		}
+	}
+}
+
+// WithPublicURLPath sets the status element that is filled with the
+// public URL for exposed workloads. The element must be declared as a
+// string at the CRD.
+func WithPublicURLPath(path string) StatusManagerFactoryOption {
+	return func(smf *statusManagerFactory) {
+		if path != "" {
+			smf.publicPath = path
+		}
+	}
+}
+
+// WithPhase sets a status element that is filled with a value derived
+// from the happy condition. The element must be declared as a string
+// at the CRD.
+func WithPhase(phase *commonv1alpha1.StatusPhase) StatusManagerFactoryOption {
+	return func(smf *statusManagerFactory) {
+		if phase == nil || phase.Path == "" {
+			return
+		}
+
+		p := phase.DeepCopy()
+		if p.Ready == "" {
+			p.Ready = defaultPhaseReady
+		}
+		if p.NotReady == "" {
+			p.NotReady = defaultPhaseNotReady
+		}
+		if p.Unknown == "" {
+			p.Unknown = defaultPhaseUnknown
+		}
+		smf.phase = p
+	}
+}
+
+const (
+	defaultAddressURLPath = "status.address.url"
+	defaultPublicURLPath  = "status.url"
+
+	defaultPhaseReady    = "Ready"
+	defaultPhaseNotReady = "NotReady"
+	defaultPhaseUnknown  = "Unknown"
+)
+
 type statusManagerFactory struct {
 	flag      crd.StatusFlag
 	happyCond string
 	conds     map[string]struct{}
 
+	// Condition types that do not vote on happiness.
+	informational map[string]struct{}
+
+	// Status element for the address URL.
+	addressPath string
+
+	// Status element for the public URL.
+	publicPath string
+
+	// Status element derived from the happy condition.
+	phase *commonv1alpha1.StatusPhase
+
 	time  Time
 	log   logr.Logger
 	mutex sync.RWMutex
 }
 
-func NewStatusManagerFactory(crdv *apiextensionsv1.CustomResourceDefinitionVersion, happyCond string, conditionSet []string, log logr.Logger) reconciler.StatusManagerFactory {
+func NewStatusManagerFactory(crdv *apiextensionsv1.CustomResourceDefinitionVersion, happyCond string, conditionSet []string, log logr.Logger, opts ...StatusManagerFactoryOption) reconciler.StatusManagerFactory {
 	smf := &statusManagerFactory{
-		flag: crd.CRDStatusFlag(crdv),
-		time: realTime{},
-		log:  log,
+		flag:        crd.CRDStatusFlag(crdv),
+		addressPath: defaultAddressURLPath,
+		publicPath:  defaultPublicURLPath,
+		time:        realTime{},
+		log:         log,
+	}
+
+	for _, opt := range opts {
+		opt(smf)
+	}
+
+	hasStatus := crdv.Subresources != nil && crdv.Subresources.Status != nil
+
+	if smf.addressPath != defaultAddressURLPath {
+		smf.flag &^= crd.StatusFlagAddressURL
+		if hasStatus && crd.HasStringField(crdv, smf.addressPath) {
+			smf.flag |= crd.StatusFlagAddressURL
+		} else {
+			log.Info("Address URL path is not declared as a string at the CRD status", "path", smf.addressPath)
+		}
+	}
+
+	if smf.publicPath != defaultPublicURLPath {
+		smf.flag &^= crd.StatusFlagPublicURL
+		if hasStatus && crd.HasStringField(crdv, smf.publicPath) {
+			smf.flag |= crd.StatusFlagPublicURL
+		} else {
+			log.Info("Public URL path is not declared as a string at the CRD status", "path", smf.publicPath)
+		}
+	}
+
+	if smf.phase != nil && !(hasStatus && crd.HasStringField(crdv, smf.phase.Path)) {
+		log.Info("Phase path is not declared as a string at the CRD status", "path", smf.phase.Path)
+		smf.phase = nil
 	}
 
 	smf.updateConditionSet(happyCond, conditionSet...)
@@ -84,6 +198,10 @@ func (smf *statusManagerFactory) ForObject(object *unstructured.Unstructured) re
 		object:             object,
 		happyConditionType: smf.happyCond,
 		conditionTypes:     smf.conds,
+		informational:      smf.informational,
+		addressPath:        strings.Split(smf.addressPath, "."),
+		publicPath:         strings.Split(smf.publicPath, "."),
+		phase:              smf.phase,
 		flag:               smf.flag,
 
 		time: smf.time,
@@ -104,6 +222,24 @@ type statusManager struct {
 	// including the happy condition type.
 	conditionTypes map[string]struct{}
 
+	// Condition types that are informed but do
+	// not vote on happiness.
+	informational map[string]struct{}
+
+	// Status element for the address URL.
+	addressPath []string
+
+	// Status element for the public URL.
+	publicPath []string
+
+	// Status element derived from the happy condition.
+	phase *commonv1alpha1.StatusPhase
+
+	// When the CRD does not support conditions but a phase
+	// is configured, conditions are kept at this detached
+	// status to compute happiness.
+	detached map[string]interface{}
+
 	// CRD information that informs about
 	// the status capabilities.
 	flag crd.StatusFlag
@@ -124,17 +260,48 @@ func (sm *statusManager) ensureStatusRoot() {
 	}
 }
 
+// conditionsEnabled returns true if conditions are written at the
+// object or are needed to compute the phase.
+func (sm *statusManager) conditionsEnabled() bool {
+	return sm.flag.AllowConditions() || sm.phase != nil
+}
+
+// conditionsStatus returns the status structure that holds conditions.
+func (sm *statusManager) conditionsStatus() map[string]interface{} {
+	if sm.flag.AllowConditions() {
+		sm.ensureStatusRoot()
+		return sm.object.Object["status"].(map[string]interface{})
+	}
+
+	if sm.detached == nil {
+		sm.detached = map[string]interface{}{}
+	}
+	return sm.detached
+}
+
+// pruneCondition removes condition elements not declared at the CRD.
+func (sm *statusManager) pruneCondition(c map[string]interface{}) {
+	if !sm.flag.AllowConditions() {
+		return
+	}
+
+	for k := range c {
+		if !sm.flag.AllowConditionField(k) {
+			delete(c, k)
+		}
+	}
+}
+
 func (sm *statusManager) sanitizeConditions() {
 	// When no flags set there status at the object's CRD.
-	if !sm.flag.AllowConditions() {
+	if !sm.conditionsEnabled() {
 		sm.log.V(2).Info("Skipping conditions: not supported by the CRD")
 		return
 	}
 
-	sm.ensureStatusRoot()
 	sm.log.V(2).Info("Ensuring status conditions")
 
-	typedStatus := sm.object.Object["status"].(map[string]interface{})
+	typedStatus := sm.conditionsStatus()
 	ecs, ok := typedStatus["conditions"]
 	if !ok {
 		ecs = make([]interface{}, 0, len(sm.conditionTypes))
@@ -210,14 +377,15 @@ func (sm *statusManager) sanitizeConditions() {
 				continue
 			}
 
-			existingConditions = append(existingConditions,
-				map[string]interface{}{
-					"type":               k,
-					"status":             string(metav1.ConditionUnknown),
-					"lastTransitionTime": tt.UTC().Format(time.RFC3339),
-					"reason":             commonv1alpha1.ConditionReasonUnknown,
-					"message":            "",
-				})
+			c := map[string]interface{}{
+				"type":               k,
+				"status":             string(metav1.ConditionUnknown),
+				"lastTransitionTime": tt.UTC().Format(time.RFC3339),
+				"reason":             commonv1alpha1.ConditionReasonUnknown,
+				"message":            "",
+			}
+			sm.pruneCondition(c)
+			existingConditions = append(existingConditions, c)
 		}
 	}
 
@@ -231,14 +399,14 @@ func (sm *statusManager) sanitizeConditions() {
 }
 
 func (sm *statusManager) GetCondition(conditionType string) *commonv1alpha1.Condition {
-	if !sm.flag.AllowConditions() {
+	if !sm.conditionsEnabled() {
 		return nil
 	}
 
 	// make sure conditions are available.
 	sm.sanitizeConditions()
 
-	existingConditions, _, _ := unstructured.NestedSlice(sm.object.Object, "status", "conditions")
+	existingConditions, _, _ := unstructured.NestedSlice(sm.conditionsStatus(), "conditions")
 
 	for i := range existingConditions {
 		c, ok := existingConditions[i].(map[string]interface{})
@@ -260,18 +428,22 @@ func (sm *statusManager) GetCondition(conditionType string) *commonv1alpha1.Cond
 		if csType == conditionType {
 			// This is the condition that we need to return
 
-			t, err := time.Parse(time.RFC3339, c["lastTransitionTime"].(string))
-			if err != nil {
-				sm.log.Error(err, "could not parse condition lastTransitionTime", "lastTransitionTime", c["lastTransitionTime"])
+			// Elements other than type and status might
+			// not be declared at the CRD.
+			cond := &commonv1alpha1.Condition{Type: conditionType}
+			cond.Status = metav1.ConditionStatus(stringElement(c, "status"))
+			cond.Reason = stringElement(c, "reason")
+			cond.Message = stringElement(c, "message")
+
+			if ltt := stringElement(c, "lastTransitionTime"); ltt != "" {
+				t, err := time.Parse(time.RFC3339, ltt)
+				if err != nil {
+					sm.log.Error(err, "could not parse condition lastTransitionTime", "lastTransitionTime", ltt)
+				}
+				cond.LastTransitionTime = metav1.NewTime(t)
 			}
 
-			return &commonv1alpha1.Condition{
-				Type:               conditionType,
-				Message:            c["message"].(string),
-				LastTransitionTime: metav1.NewTime(t),
-				Status:             metav1.ConditionStatus(c["status"].(string)),
-				Reason:             c["reason"].(string),
-			}
+			return cond
 		}
 	}
 
@@ -279,11 +451,21 @@ func (sm *statusManager) GetCondition(conditionType string) *commonv1alpha1.Cond
 	return nil
 }
 
+func (sm *statusManager) GetHappyCondition() *commonv1alpha1.Condition {
+	// Conditions kept only to compute the phase are not
+	// informed, they do not survive the reconciliation.
+	if !sm.flag.AllowConditions() {
+		return nil
+	}
+
+	return sm.GetCondition(sm.happyConditionType)
+}
+
 // SanitizeConditions makes sure the set of expected conditions exist with
 // default values, not overwritting the existing ones and removing any that
 // should not exist.
 func (sm *statusManager) SanitizeConditions() {
-	if !sm.flag.AllowConditions() {
+	if !sm.conditionsEnabled() {
 		return
 	}
 
@@ -294,7 +476,7 @@ func (sm *statusManager) SanitizeConditions() {
 }
 
 func (sm *statusManager) SetCondition(condition *commonv1alpha1.Condition) {
-	if !sm.flag.AllowConditions() {
+	if !sm.conditionsEnabled() {
 		return
 	}
 
@@ -308,7 +490,7 @@ func (sm *statusManager) SetCondition(condition *commonv1alpha1.Condition) {
 }
 
 func (sm *statusManager) setCondition(condition *commonv1alpha1.Condition) {
-	typedStatus := sm.object.Object["status"].(map[string]interface{})
+	typedStatus := sm.conditionsStatus()
 
 	ecs, ok := typedStatus["conditions"]
 	if !ok {
@@ -336,11 +518,15 @@ func (sm *statusManager) setCondition(condition *commonv1alpha1.Condition) {
 		}
 
 		if csType == condition.Type {
-			// This is the condition that we need to set
+			// This is the condition that we need to set, transition
+			// time is only updated when the status changes.
+			if c["status"] != string(condition.Status) || c["lastTransitionTime"] == nil {
+				c["lastTransitionTime"] = sm.time.Now().UTC().Format(time.RFC3339)
+			}
 			c["message"] = condition.Message
-			c["lastTransitionTime"] = sm.time.Now()
 			c["status"] = string(condition.Status)
 			c["reason"] = condition.Reason
+			sm.pruneCondition(c)
 
 			found = true
 			break
@@ -356,11 +542,11 @@ func (sm *statusManager) setCondition(condition *commonv1alpha1.Condition) {
 }
 
 func (sm *statusManager) updateConditionHappiness() {
-	if !sm.flag.AllowConditions() {
+	if !sm.conditionsEnabled() {
 		return
 	}
 
-	typedStatus := sm.object.Object["status"].(map[string]interface{})
+	typedStatus := sm.conditionsStatus()
 
 	ecs, ok := typedStatus["conditions"]
 	if !ok {
@@ -399,6 +585,10 @@ func (sm *statusManager) updateConditionHappiness() {
 			continue
 		}
 
+		if _, ok := sm.informational[csType]; ok {
+			continue
+		}
+
 		cStatus, ok := c["status"]
 		if !ok {
 			sm.log.Error(errors.New("condition does not have a status entry"), "Could not process condition happiness", "condition", c)
@@ -429,11 +619,40 @@ func (sm *statusManager) updateConditionHappiness() {
 	}
 
 	hc := conditions[happyConditionIndex].(map[string]interface{})
-	if hc["status"] != happyStatus || hc["reason"] != happyReason {
+	if hc["status"] != happyStatus ||
+		(sm.flag.AllowConditionField("reason") && hc["reason"] != happyReason) {
 		hc["status"] = happyStatus
 		hc["reason"] = happyReason
-		hc["lastTransitionTime"] = sm.time.Now()
+		hc["lastTransitionTime"] = sm.time.Now().UTC().Format(time.RFC3339)
+		sm.pruneCondition(hc)
+	}
+
+	sm.updatePhase(happyStatus)
+}
+
+// updatePhase writes the phase value that matches the happy condition status.
+func (sm *statusManager) updatePhase(happyStatus string) {
+	if sm.phase == nil {
+		return
+	}
+
+	value := sm.phase.Unknown
+	switch happyStatus {
+	case string(metav1.ConditionTrue):
+		value = sm.phase.Ready
+	case string(metav1.ConditionFalse):
+		value = sm.phase.NotReady
 	}
+
+	sm.ensureStatusRoot()
+	if err := unstructured.SetNestedField(sm.object.Object, value, strings.Split(sm.phase.Path, ".")...); err != nil {
+		sm.log.Error(err, "could not set status phase", "path", sm.phase.Path)
+	}
+}
+
+func stringElement(m map[string]interface{}, key string) string {
+	v, _ := m[key].(string)
+	return v
 }
 
 func (sm *statusManager) GetObservedGeneration() int64 {
@@ -474,48 +693,57 @@ func (sm *statusManager) GetAddressURL() string {
 		return ""
 	}
 
-	sm.m.Lock()
-	defer sm.m.Unlock()
+	sm.m.RLock()
+	defer sm.m.RUnlock()
 
-	sm.ensureStatusRoot()
-	typedStatus := sm.object.Object["status"].(map[string]interface{})
+	url, _, _ := unstructured.NestedString(sm.object.Object, sm.addressPath...)
+	return url
+}
 
-	address, ok := typedStatus["address"]
-	if !ok {
-		return ""
+func (sm *statusManager) SetAddressURL(url string) {
+	if !sm.flag.AllowAddressURL() {
+		return
 	}
 
-	typedAddress, ok := address.(map[string]interface{})
-	if !ok {
-		return ""
-	}
+	sm.m.Lock()
+	defer sm.m.Unlock()
 
-	url, ok := typedAddress["url"]
-	if !ok {
-		return ""
+	sm.ensureStatusRoot()
+	if err := unstructured.SetNestedField(sm.object.Object, url, sm.addressPath...); err != nil {
+		sm.log.Error(err, "could not set status address URL", "path", strings.Join(sm.addressPath, "."))
 	}
+}
 
-	typedUrl, ok := url.(string)
-	if !ok {
+func (sm *statusManager) GetPublicURL() string {
+	if !sm.flag.AllowPublicURL() {
 		return ""
 	}
 
-	return typedUrl
+	sm.m.RLock()
+	defer sm.m.RUnlock()
+
+	url, _, _ := unstructured.NestedString(sm.object.Object, sm.publicPath...)
+	return url
 }
 
-func (sm *statusManager) SetAddressURL(url string) {
-	if !sm.flag.AllowAddressURL() {
+// SetPublicURL writes the public URL. An empty URL removes the element,
+// since most workloads are not exposed.
+func (sm *statusManager) SetPublicURL(url string) {
+	if !sm.flag.AllowPublicURL() {
 		return
 	}
 
 	sm.m.Lock()
 	defer sm.m.Unlock()
 
-	sm.ensureStatusRoot()
-	typedStatus := sm.object.Object["status"].(map[string]interface{})
+	if url == "" {
+		unstructured.RemoveNestedField(sm.object.Object, sm.publicPath...)
+		return
+	}
 
-	typedStatus["address"] = map[string]string{
-		"url": url,
+	sm.ensureStatusRoot()
+	if err := unstructured.SetNestedField(sm.object.Object, url, sm.publicPath...); err != nil {
+		sm.log.Error(err, "could not set status public URL", "path", strings.Join(sm.publicPath, "."))
 	}
 }
 
@@ -528,6 +756,10 @@ func (sm *statusManager) SetValue(value interface{}, path ...string) error {
 }
 
 func (sm *statusManager) SetAnnotation(key, value string) error {
+	if !sm.flag.AllowAnnotations() {
+		return nil
+	}
+
 	sm.m.Lock()
 	defer sm.m.Unlock()
 
@@ -541,7 +773,7 @@ func (sm *statusManager) setAnnotation(key, value string) error {
 
 	annotations, ok := typedStatus["annotations"]
 	if !ok {
-		typedStatus["annotations"] = map[string]string{
+		typedStatus["annotations"] = map[string]interface{}{
 			key: value,
 		}
 		return nil
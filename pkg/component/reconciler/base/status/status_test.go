@@ -0,0 +1,197 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"testing"
+
+	tlogr "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	commonv1alpha1 "github.com/triggermesh/scoby/pkg/apis/common/v1alpha1"
+)
+
+var tCRDVersion = &apiextensionsv1.CustomResourceDefinitionVersion{
+	Subresources: &apiextensionsv1.CustomResourceSubresources{
+		Status: &apiextensionsv1.CustomResourceSubresourceStatus{},
+	},
+	Schema: &apiextensionsv1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+			Properties: map[string]apiextensionsv1.JSONSchemaProps{
+				"status": {
+					Type: "object",
+					Properties: map[string]apiextensionsv1.JSONSchemaProps{
+						"conditions": {
+							Type: "array",
+							Items: &apiextensionsv1.JSONSchemaPropsOrArray{
+								Schema: &apiextensionsv1.JSONSchemaProps{
+									Type: "object",
+									Properties: map[string]apiextensionsv1.JSONSchemaProps{
+										"type":               {Type: "string"},
+										"status":             {Type: "string"},
+										"reason":             {Type: "string"},
+										"message":            {Type: "string"},
+										"lastTransitionTime": {Type: "string"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+func TestConditionHappiness(t *testing.T) {
+	testCases := map[string]struct {
+		workload       metav1.ConditionStatus
+		expiring       metav1.ConditionStatus
+		expectedStatus metav1.ConditionStatus
+		expectedReason string
+	}{
+		"all true": {
+			workload:       metav1.ConditionTrue,
+			expiring:       metav1.ConditionTrue,
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: commonv1alpha1.ConditionReasonAllTrue,
+		},
+		"informational condition is not aggregated": {
+			workload:       metav1.ConditionTrue,
+			expiring:       metav1.ConditionFalse,
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: commonv1alpha1.ConditionReasonAllTrue,
+		},
+		"error condition is aggregated": {
+			workload:       metav1.ConditionFalse,
+			expiring:       metav1.ConditionTrue,
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: commonv1alpha1.ConditionReasonNotAllTrue,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			smf := NewStatusManagerFactory(tCRDVersion, "Serving",
+				[]string{"WorkloadReady", "CertificateExpiringSoon"}, tlogr.NewTestLogger(t),
+				WithConditionSeverities(map[string]commonv1alpha1.ConditionSeverity{
+					"WorkloadReady":           commonv1alpha1.ConditionSeverityError,
+					"CertificateExpiringSoon": commonv1alpha1.ConditionSeverityWarning,
+				}))
+
+			sm := smf.ForObject(&unstructured.Unstructured{})
+			sm.SetCondition(&commonv1alpha1.Condition{Type: "WorkloadReady", Status: tc.workload, Reason: "TEST"})
+			sm.SetCondition(&commonv1alpha1.Condition{Type: "CertificateExpiringSoon", Status: tc.expiring, Reason: "TEST"})
+
+			c := sm.GetCondition("Serving")
+			require.NotNil(t, c, "happy condition should be present")
+			assert.Equal(t, tc.expectedStatus, c.Status)
+			assert.Equal(t, tc.expectedReason, c.Reason)
+		})
+	}
+}
+
+// tCRDVersionCustom contains a status with a phase, an endpoint, and
+// conditions that do not declare message nor lastTransitionTime.
+var tCRDVersionCustom = &apiextensionsv1.CustomResourceDefinitionVersion{
+	Subresources: &apiextensionsv1.CustomResourceSubresources{
+		Status: &apiextensionsv1.CustomResourceSubresourceStatus{},
+	},
+	Schema: &apiextensionsv1.CustomResourceValidation{
+		OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+			Properties: map[string]apiextensionsv1.JSONSchemaProps{
+				"status": {
+					Type: "object",
+					Properties: map[string]apiextensionsv1.JSONSchemaProps{
+						"phase":    {Type: "string"},
+						"endpoint": {Type: "string"},
+						"external": {Type: "string"},
+						"conditions": {
+							Type: "array",
+							Items: &apiextensionsv1.JSONSchemaPropsOrArray{
+								Schema: &apiextensionsv1.JSONSchemaProps{
+									Type: "object",
+									Properties: map[string]apiextensionsv1.JSONSchemaProps{
+										"type":   {Type: "string"},
+										"status": {Type: "string"},
+										"reason": {Type: "string"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+func TestCustomStatusShape(t *testing.T) {
+	smf := NewStatusManagerFactory(tCRDVersionCustom, "Ready", []string{"WorkloadReady"}, tlogr.NewTestLogger(t),
+		WithAddressURLPath("status.endpoint"),
+		WithPublicURLPath("status.external"),
+		WithPhase(&commonv1alpha1.StatusPhase{Path: "status.phase", Ready: "Running"}))
+
+	u := &unstructured.Unstructured{}
+	sm := smf.ForObject(u)
+
+	sm.SetAddressURL("http://test")
+	assert.Equal(t, "http://test", sm.GetAddressURL())
+
+	sm.SetPublicURL("https://test.example.com")
+	assert.Equal(t, "https://test.example.com", sm.GetPublicURL())
+	sm.SetPublicURL("")
+	_, found, _ := unstructured.NestedString(u.Object, "status", "external")
+	assert.False(t, found)
+
+	sm.SetCondition(&commonv1alpha1.Condition{Type: "WorkloadReady", Status: metav1.ConditionFalse, Reason: "TEST", Message: "not declared"})
+
+	phase, _, _ := unstructured.NestedString(u.Object, "status", "phase")
+	assert.Equal(t, "NotReady", phase)
+
+	conditions, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	require.Len(t, conditions, 2)
+	for _, c := range conditions {
+		assert.ElementsMatch(t, []string{"type", "status", "reason"}, keys(c.(map[string]interface{})),
+			"only declared condition elements should be written")
+	}
+
+	sm.SetCondition(&commonv1alpha1.Condition{Type: "WorkloadReady", Status: metav1.ConditionTrue, Reason: "TEST"})
+
+	phase, _, _ = unstructured.NestedString(u.Object, "status", "phase")
+	assert.Equal(t, "Running", phase)
+}
+
+func TestPhaseWithoutConditions(t *testing.T) {
+	crdv := tCRDVersionCustom.DeepCopy()
+	status := crdv.Schema.OpenAPIV3Schema.Properties["status"]
+	delete(status.Properties, "conditions")
+	crdv.Schema.OpenAPIV3Schema.Properties["status"] = status
+
+	smf := NewStatusManagerFactory(crdv, "Ready", []string{"WorkloadReady"}, tlogr.NewTestLogger(t),
+		WithPhase(&commonv1alpha1.StatusPhase{Path: "status.phase"}))
+
+	u := &unstructured.Unstructured{}
+	sm := smf.ForObject(u)
+	sm.SetCondition(&commonv1alpha1.Condition{Type: "WorkloadReady", Status: metav1.ConditionTrue, Reason: "TEST"})
+
+	phase, _, _ := unstructured.NestedString(u.Object, "status", "phase")
+	assert.Equal(t, "Ready", phase)
+
+	_, found, _ := unstructured.NestedFieldNoCopy(u.Object, "status", "conditions")
+	assert.False(t, found, "conditions should not be written when not declared")
+}
+
+func keys(m map[string]interface{}) []string {
+	ks := make([]string, 0, len(m))
+	for k := range m {
+		ks = append(ks, k)
+	}
+	return ks
+}
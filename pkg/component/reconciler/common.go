@@ -5,7 +5,64 @@ package reconciler
 
 // Common status conditions
 const (
-	ConditionTypeReady = "Ready"
+	ConditionTypeReady       = "Ready"
+	ConditionTypeRenderReady = "RenderReady"
+	ConditionTypeHookReady   = "HookReady"
+)
+
+// Render status condition reasons
+const (
+	ConditionReasonRenderOK        = "RENDEROK"
+	ConditionReasonRenderFailed    = "RENDERFAILED"
+	ConditionReasonPreRenderFailed = "PRERENDERFAILED"
+)
+
+// Hook status condition reasons
+const (
+	ConditionReasonHookOK          = "HOOKOK"
+	ConditionReasonHookFailed      = "HOOKFAILED"
+	ConditionReasonHookCircuitOpen = "HOOKCIRCUITOPEN"
+	ConditionReasonHookNotCalled   = "HOOKNOTCALLED"
+)
+
+// Hook events reasons
+const (
+	EventReasonHookFailed    = "HookFailed"
+	EventReasonHookRetry     = "HookRetry"
+	EventReasonHookContinued = "HookContinued"
+)
+
+// Lifecycle events reasons
+const (
+	EventReasonChildCreated     = "ChildCreated"
+	EventReasonChildUpdated     = "ChildUpdated"
+	EventReasonChildDeleted     = "ChildDeleted"
+	EventReasonChildFailed      = "ChildFailed"
+	EventReasonRenderFailed     = "RenderFailed"
+	EventReasonFinalizerAdded   = "FinalizerAdded"
+	EventReasonFinalizerRemoved = "FinalizerRemoved"
+	EventReasonReady            = "Ready"
+	EventReasonNotReady         = "NotReady"
+)
+
+// Status annotations that keep track of the last hook call.
+const (
+	StatusAnnotationHookLastPhase   = "hookLastPhase"
+	StatusAnnotationHookLastResult  = "hookLastResult"
+	StatusAnnotationHookLastLatency = "hookLastLatency"
+	StatusAnnotationHookLastError   = "hookLastError"
+)
+
+// StatusAnnotationChildrenKinds keeps the kinds of the children created
+// for the instance, used to find stale children after a restart.
+const StatusAnnotationChildrenKinds = "childrenKinds"
+
+// Results for the last hook call status annotation.
+const (
+	HookResultSuccess     = "Success"
+	HookResultFailed      = "Failed"
+	HookResultContinued   = "Continued"
+	HookResultCircuitOpen = "CircuitOpen"
 )
 
 const (
@@ -17,3 +74,10 @@ const (
 const (
 	DefaultContainerName = "adapter"
 )
+
+const (
+	// ChildLabel is set on children objects that are not generated by
+	// the form factor, and contains the key that identifies them at the
+	// children map.
+	ChildLabel = "scoby.triggermesh.io/child"
+)
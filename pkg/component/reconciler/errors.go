@@ -0,0 +1,21 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler
+
+import (
+	"time"
+)
+
+// HookCircuitOpenError is returned by hook reconcilers when calls are
+// being rejected because the circuit breaker is open.
+type HookCircuitOpenError struct {
+	// RetryAfter is the time left until the hook can be called again.
+	RetryAfter time.Duration
+}
+
+// Error does not include the retry delay, keeping the message stable
+// while the circuit is open.
+func (e *HookCircuitOpenError) Error() string {
+	return "hook circuit breaker is open"
+}
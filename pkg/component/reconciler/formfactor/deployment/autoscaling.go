@@ -0,0 +1,258 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/triggermesh/scoby/pkg/component/reconciler"
+	"github.com/triggermesh/scoby/pkg/utils/resources"
+	"github.com/triggermesh/scoby/pkg/utils/semantic"
+)
+
+// Children map keys for the autoscaler and disruption budget.
+const (
+	horizontalPodAutoscalerKey = "horizontalpodautoscaler"
+	podDisruptionBudgetKey     = "poddisruptionbudget"
+)
+
+func (dr *deploymentReconciler) metaOptions(obj reconciler.Object) []resources.MetaOption {
+	return []resources.MetaOption{
+		resources.MetaAddLabel(resources.AppNameLabel, dr.name),
+		resources.MetaAddLabel(resources.AppInstanceLabel, obj.GetName()),
+		resources.MetaAddLabel(resources.AppComponentLabel, reconciler.ComponentWorkload),
+		resources.MetaAddLabel(resources.AppPartOfLabel, reconciler.PartOf),
+		resources.MetaAddLabel(resources.AppManagedByLabel, reconciler.ManagedBy),
+		resources.MetaAddOwner(obj, obj.GetObjectKind().GroupVersionKind()),
+	}
+}
+
+func (dr *deploymentReconciler) createHorizontalPodAutoscalerFromRegistered(obj reconciler.Object) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	as := dr.formFactor.Autoscaling
+
+	min, max := as.MinReplicas, as.MaxReplicas
+	if fs := as.FromSpec; fs != nil {
+		if v, ok, err := instanceInt32(obj, fs.MinReplicas); err != nil {
+			return nil, err
+		} else if ok {
+			min = &v
+		}
+
+		if v, ok, err := instanceInt32(obj, fs.MaxReplicas); err != nil {
+			return nil, err
+		} else if ok {
+			max = v
+		}
+	}
+
+	if min != nil && *min > max {
+		return nil, fmt.Errorf("autoscaling min replicas %d is greater than max replicas %d", *min, max)
+	}
+
+	opts := []resources.HorizontalPodAutoscalerOption{
+		resources.HorizontalPodAutoscalerWithMetaOptions(dr.metaOptions(obj)...),
+		resources.HorizontalPodAutoscalerForDeployment(dr.name + "-" + obj.GetName()),
+		resources.HorizontalPodAutoscalerWithReplicas(min, max),
+	}
+
+	if as.TargetCPUUtilizationPercentage != nil {
+		opts = append(opts, resources.HorizontalPodAutoscalerAddResourceUtilization(
+			corev1.ResourceCPU, *as.TargetCPUUtilizationPercentage))
+	}
+	if as.TargetMemoryUtilizationPercentage != nil {
+		opts = append(opts, resources.HorizontalPodAutoscalerAddResourceUtilization(
+			corev1.ResourceMemory, *as.TargetMemoryUtilizationPercentage))
+	}
+	if len(as.Metrics) != 0 {
+		opts = append(opts, resources.HorizontalPodAutoscalerAddMetrics(as.Metrics...))
+	}
+
+	return resources.NewHorizontalPodAutoscaler(obj.GetNamespace(), dr.name+"-"+obj.GetName(), opts...), nil
+}
+
+func (dr *deploymentReconciler) createPodDisruptionBudgetFromRegistered(obj reconciler.Object) (*policyv1.PodDisruptionBudget, error) {
+	pdb := dr.formFactor.PodDisruptionBudget
+	if (pdb.MinAvailable == nil) == (pdb.MaxUnavailable == nil) {
+		return nil, fmt.Errorf("pod disruption budget requires either min available or max unavailable")
+	}
+
+	return resources.NewPodDisruptionBudget(obj.GetNamespace(), dr.name+"-"+obj.GetName(),
+		resources.PodDisruptionBudgetWithMetaOptions(dr.metaOptions(obj)...),
+		resources.PodDisruptionBudgetAddSelectorLabel(resources.AppNameLabel, dr.name),
+		resources.PodDisruptionBudgetAddSelectorLabel(resources.AppInstanceLabel, obj.GetName()),
+		resources.PodDisruptionBudgetAddSelectorLabel(resources.AppComponentLabel, reconciler.ComponentWorkload),
+		resources.PodDisruptionBudgetWithMinAvailable(pdb.MinAvailable),
+		resources.PodDisruptionBudgetWithMaxUnavailable(pdb.MaxUnavailable),
+	), nil
+}
+
+// reconcileScaling manages the autoscaler and disruption budget candidates.
+// Nil candidates are the marker used by hooks to omit them. Objects are
+// deleted when their section is removed from the registration.
+func (dr *deploymentReconciler) reconcileScaling(ctx context.Context, obj reconciler.Object, objects map[string]*unstructured.Unstructured) error {
+	var autoscaling, pdb bool
+	if dr.formFactor != nil {
+		autoscaling = dr.formFactor.Autoscaling != nil
+		pdb = dr.formFactor.PodDisruptionBudget != nil
+	}
+
+	var err error
+	if autoscaling {
+		err = dr.reconcileCandidate(ctx, obj, objects, horizontalPodAutoscalerKey,
+			&autoscalingv2.HorizontalPodAutoscaler{}, &autoscalingv2.HorizontalPodAutoscaler{}, "HorizontalPodAutoscaler")
+	} else {
+		err = dr.deleteRemoved(ctx, obj, &autoscalingv2.HorizontalPodAutoscaler{}, "HorizontalPodAutoscaler")
+	}
+	if err != nil {
+		return err
+	}
+
+	if pdb {
+		return dr.reconcileCandidate(ctx, obj, objects, podDisruptionBudgetKey,
+			&policyv1.PodDisruptionBudget{}, &policyv1.PodDisruptionBudget{}, "PodDisruptionBudget")
+	}
+
+	return dr.deleteRemoved(ctx, obj, &policyv1.PodDisruptionBudget{}, "PodDisruptionBudget")
+}
+
+// reconcileCandidate converts the candidate at the key into the desired
+// object and reconciles it, or deletes the existing one when omitted.
+func (dr *deploymentReconciler) reconcileCandidate(ctx context.Context, obj reconciler.Object, objects map[string]*unstructured.Unstructured, key string, desired, existing client.Object, kind string) error {
+	u, ok := objects[key]
+	if !ok {
+		return fmt.Errorf("could not get %s from rendered candidates list: %+v", strings.ToLower(kind), objects)
+	}
+
+	if u == nil {
+		return dr.deleteOwned(ctx, obj, existing, kind)
+	}
+
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, desired); err != nil {
+		return fmt.Errorf("%s from rendered candidates is not a %s object: %w", strings.ToLower(kind), kind, err)
+	}
+
+	return dr.reconcileOwned(ctx, obj, desired, existing, kind)
+}
+
+// instanceInt32 returns the integer at the instance path. False is returned
+// when the path is empty or the element does not exist.
+func instanceInt32(obj reconciler.Object, path string) (int32, bool, error) {
+	if path == "" {
+		return 0, false, nil
+	}
+
+	u, ok := obj.AsKubeObject().(*unstructured.Unstructured)
+	if !ok {
+		return 0, false, fmt.Errorf("could not parse object into unstructured: %s", obj.GetName())
+	}
+
+	v, ok, err := unstructured.NestedInt64(u.Object, strings.Split(path, ".")...)
+	if err != nil {
+		return 0, false, fmt.Errorf("element at %s is expected to be an integer", path)
+	}
+
+	return int32(v), ok, nil
+}
+
+// reconcileOwned creates or updates an object owned by the instance.
+func (dr *deploymentReconciler) reconcileOwned(ctx context.Context, obj reconciler.Object, desired, existing client.Object, kind string) error {
+	dr.log.V(1).Info("reconciling "+strings.ToLower(kind), "object", obj)
+
+	err := dr.client.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	switch {
+	case err == nil:
+		if semantic.Semantic.DeepEqual(desired, existing) {
+			return nil
+		}
+
+		dr.log.Info("existing "+strings.ToLower(kind)+" does not match the expected", "object", desired)
+
+		// resourceVersion must be returned to the API server unmodified for
+		// optimistic concurrency, as per Kubernetes API conventions
+		desired.SetResourceVersion(existing.GetResourceVersion())
+
+		if err = dr.client.Update(ctx, desired); err != nil {
+			dr.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeWarning, reconciler.EventReasonChildFailed,
+				"Failed to update %s %s: %v", kind, desired.GetName(), err)
+			return fmt.Errorf("could not update %s object: %w", strings.ToLower(kind), err)
+		}
+		dr.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeNormal, reconciler.EventReasonChildUpdated,
+			"Updated %s %s", kind, desired.GetName())
+
+	case apierrs.IsNotFound(err):
+		dr.log.Info("creating "+strings.ToLower(kind), "object", desired)
+		if err = dr.client.Create(ctx, desired); err != nil {
+			dr.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeWarning, reconciler.EventReasonChildFailed,
+				"Failed to create %s %s: %v", kind, desired.GetName(), err)
+			return fmt.Errorf("could not create %s object: %w", strings.ToLower(kind), err)
+		}
+		dr.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeNormal, reconciler.EventReasonChildCreated,
+			"Created %s %s", kind, desired.GetName())
+
+	default:
+		return fmt.Errorf("could not retrieve controlled %s %s: %w", strings.ToLower(kind), client.ObjectKeyFromObject(desired), err)
+	}
+
+	return nil
+}
+
+// deleteOwned removes an object controlled by the instance, if any.
+func (dr *deploymentReconciler) deleteOwned(ctx context.Context, obj reconciler.Object, existing client.Object, kind string) error {
+	err := dr.client.Get(ctx, client.ObjectKey{Namespace: obj.GetNamespace(), Name: dr.name + "-" + obj.GetName()}, existing)
+	switch {
+	case apierrs.IsNotFound(err):
+		return nil
+	case err != nil:
+		return fmt.Errorf("could not retrieve controlled %s: %w", strings.ToLower(kind), err)
+	}
+
+	return dr.deleteControlled(ctx, obj, existing, kind)
+}
+
+// deleteRemoved removes an object controlled by the instance, if any, whose
+// section was removed from the registration. Those objects are not watched,
+// they are retrieved using the API reader to avoid starting informers, and
+// kinds not served by the cluster are considered to have nothing to delete.
+func (dr *deploymentReconciler) deleteRemoved(ctx context.Context, obj reconciler.Object, existing client.Object, kind string) error {
+	err := dr.apiReader.Get(ctx, client.ObjectKey{Namespace: obj.GetNamespace(), Name: dr.name + "-" + obj.GetName()}, existing)
+	switch {
+	case apierrs.IsNotFound(err), meta.IsNoMatchError(err):
+		return nil
+	case err != nil:
+		return fmt.Errorf("could not retrieve controlled %s: %w", strings.ToLower(kind), err)
+	}
+
+	return dr.deleteControlled(ctx, obj, existing, kind)
+}
+
+// deleteControlled removes the existing object when controlled by the instance.
+func (dr *deploymentReconciler) deleteControlled(ctx context.Context, obj reconciler.Object, existing client.Object, kind string) error {
+	if !metav1.IsControlledBy(existing, obj) {
+		return nil
+	}
+
+	dr.log.Info("deleting "+strings.ToLower(kind), "object", existing)
+	if err := dr.client.Delete(ctx, existing); err != nil && !apierrs.IsNotFound(err) {
+		dr.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeWarning, reconciler.EventReasonChildFailed,
+			"Failed to delete %s %s: %v", kind, existing.GetName(), err)
+		return fmt.Errorf("could not delete %s object: %w", strings.ToLower(kind), err)
+	}
+	dr.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeNormal, reconciler.EventReasonChildDeleted,
+		"Deleted %s %s", kind, existing.GetName())
+
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package deployment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	commonv1alpha1 "github.com/triggermesh/scoby/pkg/apis/common/v1alpha1"
+)
+
+func TestReconcileScalingRemoved(t *testing.T) {
+	testCases := map[string]*commonv1alpha1.DeploymentFormFactor{
+		"sections removed":    {},
+		"form factor removed": nil,
+	}
+
+	for name, ff := range testCases {
+		t.Run(name, func(t *testing.T) {
+			obj := newTestObject(t)
+			owner := *metav1.NewControllerRef(obj, *tGVK)
+
+			hpa := &autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{
+				Namespace:       "test",
+				Name:            "kuard-my-kuard",
+				OwnerReferences: []metav1.OwnerReference{owner},
+			}}
+			pdb := &policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{
+				Namespace:       "test",
+				Name:            "kuard-my-kuard",
+				OwnerReferences: []metav1.OwnerReference{owner},
+			}}
+
+			dr := newTestReconciler(t, ff, hpa, pdb)
+			ctx := context.Background()
+			require.NoError(t, dr.reconcileScaling(ctx, obj, map[string]*unstructured.Unstructured{}))
+
+			err := dr.client.Get(ctx, client.ObjectKeyFromObject(hpa), &autoscalingv2.HorizontalPodAutoscaler{})
+			assert.True(t, apierrs.IsNotFound(err), "horizontal pod autoscaler was not deleted: %v", err)
+
+			err = dr.client.Get(ctx, client.ObjectKeyFromObject(pdb), &policyv1.PodDisruptionBudget{})
+			assert.True(t, apierrs.IsNotFound(err), "pod disruption budget was not deleted: %v", err)
+		})
+	}
+}
+
+// noMatchReader fails retrieving objects of kinds not served by the cluster.
+type noMatchReader struct {
+	client.Reader
+}
+
+func (r *noMatchReader) Get(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	return &meta.NoKindMatchError{GroupKind: obj.GetObjectKind().GroupVersionKind().GroupKind()}
+}
+
+func TestReconcileScalingKindNotServed(t *testing.T) {
+	dr := newTestReconciler(t, &commonv1alpha1.DeploymentFormFactor{})
+	dr.apiReader = &noMatchReader{}
+
+	assert.NoError(t, dr.reconcileScaling(context.Background(), newTestObject(t), map[string]*unstructured.Unstructured{}))
+}
@@ -0,0 +1,314 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	commonv1alpha1 "github.com/triggermesh/scoby/pkg/apis/common/v1alpha1"
+	"github.com/triggermesh/scoby/pkg/component/reconciler"
+	"github.com/triggermesh/scoby/pkg/utils/resources"
+)
+
+// Children map keys for the objects that expose the service.
+const (
+	ingressKey   = "ingress"
+	httpRouteKey = "httproute"
+)
+
+// exposeKey returns the children map key for the exposing object.
+func (dr *deploymentReconciler) exposeKey() string {
+	if dr.serviceOptions.Expose.HTTPRoute != nil {
+		return httpRouteKey
+	}
+	return ingressKey
+}
+
+// createExposureFromRegistered returns the Ingress or HTTPRoute that
+// routes external requests to the service, as an unstructured object.
+func (dr *deploymentReconciler) createExposureFromRegistered(obj reconciler.Object) (*unstructured.Unstructured, error) {
+	ex := dr.serviceOptions.Expose
+	if (ex.Ingress == nil) == (ex.HTTPRoute == nil) {
+		return nil, fmt.Errorf("service exposure requires either ingress or httpRoute")
+	}
+
+	host, err := dr.exposeHost(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	name := dr.name + "-" + obj.GetName()
+	port := dr.servicePort(ex.Port).Port
+
+	var o interface{}
+	switch {
+	case ex.Ingress != nil:
+		mopts := dr.metaOptions(obj)
+		for k, v := range ex.Ingress.Annotations {
+			mopts = append(mopts, resources.MetaAddAnnotation(k, v))
+		}
+
+		opts := []resources.IngressOption{
+			resources.IngressWithMetaOptions(mopts...),
+			resources.IngressWithClassName(ex.Ingress.ClassName),
+			resources.IngressAddRuleForService(host, exposePath(ex), name, port),
+		}
+
+		if ex.TLS != nil {
+			secretName, err := executeExposeTemplate(obj, "tlsSecretName", ex.TLS.SecretName)
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, resources.IngressAddTLS(secretName, host))
+		}
+
+		o = resources.NewIngress(obj.GetNamespace(), name, opts...)
+
+	default:
+		if ex.TLS != nil && ex.TLS.SecretName != "" {
+			return nil, fmt.Errorf("HTTPRoute TLS secrets must be referenced at the Gateway listener")
+		}
+
+		opts := []resources.HTTPRouteOption{
+			resources.HTTPRouteWithMetaOptions(dr.metaOptions(obj)...),
+			resources.HTTPRouteAddHostname(host),
+			resources.HTTPRouteAddRuleForService(exposePath(ex), name, port),
+		}
+		for _, pr := range ex.HTTPRoute.ParentRefs {
+			opts = append(opts, resources.HTTPRouteAddParentRef(pr.Namespace, pr.Name, pr.SectionName))
+		}
+
+		o = resources.NewHTTPRoute(obj.GetNamespace(), name, opts...)
+	}
+
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(o)
+	if err != nil {
+		return nil, fmt.Errorf("exposing object cannot be converted into unstructured: %w", err)
+	}
+
+	return &unstructured.Unstructured{Object: u}, nil
+}
+
+// exposeHost executes the host template and validates the result.
+func (dr *deploymentReconciler) exposeHost(obj reconciler.Object) (string, error) {
+	host, err := executeExposeTemplate(obj, "host", dr.serviceOptions.Expose.Host)
+	if err != nil {
+		return "", err
+	}
+
+	if errs := validation.IsDNS1123Subdomain(host); len(errs) != 0 {
+		return "", fmt.Errorf("exposed host %q is not valid: %s", host, strings.Join(errs, ", "))
+	}
+
+	return host, nil
+}
+
+// executeExposeTemplate renders an exposure template using the
+// instance name, namespace and spec.
+func executeExposeTemplate(obj reconciler.Object, name, text string) (string, error) {
+	t, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("could not parse %s template: %w", name, err)
+	}
+
+	var spec map[string]interface{}
+	if u, ok := obj.AsKubeObject().(*unstructured.Unstructured); ok {
+		spec, _, _ = unstructured.NestedMap(u.Object, "spec")
+	}
+
+	b := &strings.Builder{}
+	if err := t.Execute(b, struct {
+		Name      string
+		Namespace string
+		Spec      map[string]interface{}
+	}{
+		Name:      obj.GetName(),
+		Namespace: obj.GetNamespace(),
+		Spec:      spec,
+	}); err != nil {
+		return "", fmt.Errorf("could not execute %s template: %w", name, err)
+	}
+
+	return b.String(), nil
+}
+
+func exposePath(ex *commonv1alpha1.DeploymentServiceExpose) string {
+	if ex.Path == "" {
+		return "/"
+	}
+	return ex.Path
+}
+
+// reconcileExposure manages the exposing object candidate. A nil candidate
+// is the marker used by hooks to omit it.
+func (dr *deploymentReconciler) reconcileExposure(ctx context.Context, obj reconciler.Object, objects map[string]*unstructured.Unstructured) error {
+	key := dr.exposeKey()
+	u, ok := objects[key]
+	if !ok {
+		return fmt.Errorf("could not get %s from rendered candidates list: %+v", key, objects)
+	}
+
+	if u == nil {
+		if err := dr.deleteExposure(ctx, obj); err != nil {
+			return err
+		}
+		dr.updateExposureOmittedStatus(obj)
+		return nil
+	}
+
+	if key == ingressKey {
+		desired, existing := &networkingv1.Ingress{}, &networkingv1.Ingress{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, desired); err != nil {
+			return fmt.Errorf("ingress from rendered candidates is not an Ingress object: %w", err)
+		}
+
+		if err := dr.reconcileOwned(ctx, obj, desired, existing, "Ingress"); err != nil {
+			return err
+		}
+
+		dr.updateExposureStatus(obj, ingressPending(existing))
+		return nil
+	}
+
+	desired := u.DeepCopy()
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(resources.HTTPRouteGroupVersionKind)
+	if err := dr.reconcileOwned(ctx, obj, desired, existing, "HTTPRoute"); err != nil {
+		return err
+	}
+
+	dr.updateExposureStatus(obj, httpRouteFailure(existing))
+	return nil
+}
+
+// deleteExposure removes the exposing object created for the instance.
+func (dr *deploymentReconciler) deleteExposure(ctx context.Context, obj reconciler.Object) error {
+	if dr.exposeKey() == ingressKey {
+		return dr.deleteOwned(ctx, obj, &networkingv1.Ingress{}, "Ingress")
+	}
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(resources.HTTPRouteGroupVersionKind)
+	return dr.deleteOwned(ctx, obj, existing, "HTTPRoute")
+}
+
+// ingressPending returns a pending condition until the ingress controller
+// informs a load balancer address at the Ingress status.
+func ingressPending(ingress *networkingv1.Ingress) *commonv1alpha1.Condition {
+	for _, lb := range ingress.Status.LoadBalancer.Ingress {
+		if lb.IP != "" || lb.Hostname != "" {
+			return nil
+		}
+	}
+
+	return &commonv1alpha1.Condition{
+		Status:  metav1.ConditionUnknown,
+		Reason:  "IngressPending",
+		Message: "Ingress status has not been informed a load balancer address",
+	}
+}
+
+// httpRouteFailure returns the first condition that reports the route
+// was not accepted by, or could not be resolved at, a parent Gateway.
+// A pending condition is returned when no Gateway informed the route status.
+func httpRouteFailure(route *unstructured.Unstructured) *commonv1alpha1.Condition {
+	parents, _, _ := unstructured.NestedSlice(route.Object, "status", "parents")
+	if len(parents) == 0 {
+		return &commonv1alpha1.Condition{
+			Status:  metav1.ConditionUnknown,
+			Reason:  "HTTPRoutePending",
+			Message: "HTTPRoute status has not been informed by any Gateway",
+		}
+	}
+
+	for _, p := range parents {
+		pm, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		conditions, _, _ := unstructured.NestedSlice(pm, "conditions")
+		for _, c := range conditions {
+			cm, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			if cm["status"] != string(metav1.ConditionFalse) {
+				continue
+			}
+
+			switch cm["type"] {
+			case "Accepted", "ResolvedRefs":
+				reason, _ := cm["reason"].(string)
+				message, _ := cm["message"].(string)
+				return &commonv1alpha1.Condition{
+					Status:  metav1.ConditionFalse,
+					Reason:  reason,
+					Message: message,
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (dr *deploymentReconciler) updateExposureOmittedStatus(obj reconciler.Object) {
+	sm := obj.GetStatusManager()
+	sm.SetPublicURL("")
+	sm.SetCondition(&commonv1alpha1.Condition{
+		Type:               ConditionTypeExposureReady,
+		Reason:             "ExposureOmitted",
+		Status:             metav1.ConditionTrue,
+		Message:            "Exposure omitted by hook",
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+// updateExposureStatus informs the exposure condition, which is ready unless
+// a failure is informed. The public URL is only informed when ready.
+func (dr *deploymentReconciler) updateExposureStatus(obj reconciler.Object, failure *commonv1alpha1.Condition) {
+	dr.log.V(1).Info("updating exposure status", "object", obj)
+
+	desired := &commonv1alpha1.Condition{
+		Type:               ConditionTypeExposureReady,
+		Reason:             "ExposureExist",
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+	}
+
+	if failure != nil {
+		desired.Status = failure.Status
+		desired.Reason = failure.Reason
+		desired.Message = failure.Message
+	}
+
+	url := ""
+	if host, err := dr.exposeHost(obj); err == nil && failure == nil {
+		ex := dr.serviceOptions.Expose
+		scheme := "http"
+		if ex.TLS != nil {
+			scheme = "https"
+		}
+
+		url = scheme + "://" + host
+		if p := exposePath(ex); p != "/" {
+			url += p
+		}
+	}
+
+	sm := obj.GetStatusManager()
+	sm.SetPublicURL(url)
+	sm.SetCondition(desired)
+}
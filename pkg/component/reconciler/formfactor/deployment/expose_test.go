@@ -0,0 +1,149 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package deployment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	commonv1alpha1 "github.com/triggermesh/scoby/pkg/apis/common/v1alpha1"
+)
+
+func TestReconcileExposureStatus(t *testing.T) {
+	ingress := &commonv1alpha1.DeploymentServiceExpose{
+		Host:    "{{.Name}}.example.com",
+		Ingress: &commonv1alpha1.DeploymentServiceIngress{},
+	}
+	httpRoute := &commonv1alpha1.DeploymentServiceExpose{
+		Host: "{{.Name}}.example.com",
+		HTTPRoute: &commonv1alpha1.DeploymentServiceHTTPRoute{
+			ParentRefs: []commonv1alpha1.HTTPRouteParentReference{{Name: "gateway"}},
+		},
+	}
+
+	withStatus := func(status map[string]interface{}) func(*testing.T, *unstructured.Unstructured) {
+		return func(t *testing.T, u *unstructured.Unstructured) {
+			require.NoError(t, unstructured.SetNestedMap(u.Object, status, "status"))
+		}
+	}
+
+	testCases := map[string]struct {
+		expose *commonv1alpha1.DeploymentServiceExpose
+		// existing object status, the object does not exist when nil.
+		existingStatus func(*testing.T, *unstructured.Unstructured)
+
+		expectedStatus metav1.ConditionStatus
+		expectedReason string
+		expectedURL    string
+	}{
+		"ingress created": {
+			expose:         ingress,
+			expectedStatus: metav1.ConditionUnknown,
+			expectedReason: "IngressPending",
+		},
+		"ingress without load balancer address": {
+			expose: ingress,
+			existingStatus: withStatus(map[string]interface{}{
+				"loadBalancer": map[string]interface{}{},
+			}),
+			expectedStatus: metav1.ConditionUnknown,
+			expectedReason: "IngressPending",
+		},
+		"ingress with load balancer address": {
+			expose: ingress,
+			existingStatus: withStatus(map[string]interface{}{
+				"loadBalancer": map[string]interface{}{
+					"ingress": []interface{}{
+						map[string]interface{}{"ip": "203.0.113.10"},
+					},
+				},
+			}),
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: "ExposureExist",
+			expectedURL:    "http://my-kuard.example.com",
+		},
+		"httproute created": {
+			expose:         httpRoute,
+			expectedStatus: metav1.ConditionUnknown,
+			expectedReason: "HTTPRoutePending",
+		},
+		"httproute not accepted": {
+			expose: httpRoute,
+			existingStatus: withStatus(map[string]interface{}{
+				"parents": []interface{}{
+					map[string]interface{}{
+						"conditions": []interface{}{
+							map[string]interface{}{
+								"type":    "Accepted",
+								"status":  "False",
+								"reason":  "NotAllowedByListeners",
+								"message": "No listener allows the route",
+							},
+						},
+					},
+				},
+			}),
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: "NotAllowedByListeners",
+		},
+		"httproute accepted": {
+			expose: httpRoute,
+			existingStatus: withStatus(map[string]interface{}{
+				"parents": []interface{}{
+					map[string]interface{}{
+						"conditions": []interface{}{
+							map[string]interface{}{
+								"type":   "Accepted",
+								"status": "True",
+								"reason": "Accepted",
+							},
+						},
+					},
+				},
+			}),
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: "ExposureExist",
+			expectedURL:    "http://my-kuard.example.com",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ff := &commonv1alpha1.DeploymentFormFactor{
+				Service: &commonv1alpha1.DeploymentService{Port: 80, Expose: tc.expose},
+			}
+			obj := newTestObject(t, ConditionTypeDeploymentReady, ConditionTypeServiceReady, ConditionTypeExposureReady)
+
+			candidate, err := newTestReconciler(t, ff).createExposureFromRegistered(obj)
+			require.NoError(t, err)
+
+			var objects []client.Object
+			if tc.existingStatus != nil {
+				existing := candidate.DeepCopy()
+				tc.existingStatus(t, existing)
+				objects = append(objects, existing)
+			}
+
+			dr := newTestReconciler(t, ff, objects...)
+			require.NoError(t, dr.reconcileExposure(context.Background(), obj, map[string]*unstructured.Unstructured{
+				dr.exposeKey(): candidate,
+			}))
+
+			sm := obj.GetStatusManager()
+			c := sm.GetCondition(ConditionTypeExposureReady)
+			require.NotNil(t, c)
+			assert.Equal(t, tc.expectedStatus, c.Status)
+			assert.Equal(t, tc.expectedReason, c.Reason)
+			assert.Equal(t, tc.expectedURL, sm.GetPublicURL())
+		})
+	}
+}
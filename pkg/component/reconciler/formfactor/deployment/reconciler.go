@@ -6,17 +6,24 @@ package deployment
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/go-logr/logr"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -26,6 +33,7 @@ import (
 	commonv1alpha1 "github.com/triggermesh/scoby/pkg/apis/common/v1alpha1"
 	hookv1 "github.com/triggermesh/scoby/pkg/apis/hook/v1"
 	"github.com/triggermesh/scoby/pkg/component/reconciler"
+	"github.com/triggermesh/scoby/pkg/utils/diagnostics"
 	"github.com/triggermesh/scoby/pkg/utils/resolver"
 	"github.com/triggermesh/scoby/pkg/utils/resources"
 	"github.com/triggermesh/scoby/pkg/utils/semantic"
@@ -36,17 +44,29 @@ const (
 
 	ConditionTypeDeploymentReady = "DeploymentReady"
 	ConditionTypeServiceReady    = "ServiceReady"
+	ConditionTypeExposureReady   = "ExposureReady"
 )
 
-func New(name string, wkl *commonv1alpha1.Workload, mgr ctrl.Manager) reconciler.FormFactorReconciler {
+func New(name string, wkl *commonv1alpha1.Workload, mgr ctrl.Manager, recorder record.EventRecorder, opts ...reconciler.FormFactorOption) reconciler.FormFactorReconciler {
+	o := &reconciler.FormFactorOptions{
+		Cache:  mgr.GetCache(),
+		Client: mgr.GetClient(),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	dr := &deploymentReconciler{
 		name:       name,
 		formFactor: wkl.FormFactor.Deployment,
 		fromImage:  &wkl.FromImage,
 
-		mgr:    mgr,
-		client: mgr.GetClient(),
-		log:    mgr.GetLogger(),
+		mgr:       mgr,
+		cache:     o.Cache,
+		client:    o.Client,
+		apiReader: mgr.GetAPIReader(),
+		recorder:  recorder,
+		log:       mgr.GetLogger(),
 		info: &hookv1.FormFactorInfo{
 			Name: "deployment",
 		},
@@ -65,10 +85,15 @@ type deploymentReconciler struct {
 	fromImage      *commonv1alpha1.RegistrationFromImage
 	serviceOptions *commonv1alpha1.DeploymentService
 
-	mgr    ctrl.Manager
-	client client.Client
-	log    logr.Logger
-	info   *hookv1.FormFactorInfo
+	mgr      ctrl.Manager
+	cache    cache.Cache
+	client   client.Client
+	recorder record.EventRecorder
+	log      logr.Logger
+	info     *hookv1.FormFactorInfo
+
+	// reader for objects that are not watched.
+	apiReader client.Reader
 }
 
 var _ reconciler.FormFactorReconciler = (*deploymentReconciler)(nil)
@@ -81,6 +106,10 @@ func (dr *deploymentReconciler) GetStatusConditions() (happy string, all []strin
 	// status condition.
 	if dr.formFactor != nil && dr.formFactor.Service != nil {
 		all = append(all, ConditionTypeServiceReady)
+
+		if dr.formFactor.Service.Expose != nil {
+			all = append(all, ConditionTypeExposureReady)
+		}
 	}
 
 	return
@@ -92,7 +121,7 @@ func (dr *deploymentReconciler) GetInfo() *hookv1.FormFactorInfo {
 
 func (dr *deploymentReconciler) SetupController(name string, c controller.Controller, owner client.Object) error {
 	dr.log.Info("Setting up deployment styled reconciler", "registration", name)
-	if err := c.Watch(source.Kind(dr.mgr.GetCache(), &appsv1.Deployment{}),
+	if err := c.Watch(source.Kind(dr.cache, &appsv1.Deployment{}),
 		handler.EnqueueRequestForOwner(
 			dr.mgr.GetScheme(),
 			dr.mgr.GetRESTMapper(),
@@ -101,7 +130,7 @@ func (dr *deploymentReconciler) SetupController(name string, c controller.Contro
 		return fmt.Errorf("could not set watcher on deployments owned by registered object %q: %w", name, err)
 	}
 
-	if err := c.Watch(source.Kind(dr.mgr.GetCache(), &corev1.Service{}), handler.EnqueueRequestForOwner(
+	if err := c.Watch(source.Kind(dr.cache, &corev1.Service{}), handler.EnqueueRequestForOwner(
 		dr.mgr.GetScheme(),
 		dr.mgr.GetRESTMapper(),
 		owner,
@@ -109,9 +138,82 @@ func (dr *deploymentReconciler) SetupController(name string, c controller.Contro
 		return fmt.Errorf("could not set watcher on services owned by registered object %q: %w", name, err)
 	}
 
+	if dr.formFactor == nil {
+		return nil
+	}
+
+	if dr.serviceOptions != nil && dr.serviceOptions.Expose != nil {
+		var o client.Object = &networkingv1.Ingress{}
+		if dr.exposeKey() == httpRouteKey {
+			u := &unstructured.Unstructured{}
+			u.SetGroupVersionKind(resources.HTTPRouteGroupVersionKind)
+			o = u
+		}
+
+		if err := c.Watch(source.Kind(dr.cache, o), handler.EnqueueRequestForOwner(
+			dr.mgr.GetScheme(),
+			dr.mgr.GetRESTMapper(),
+			owner,
+			handler.OnlyControllerOwner())); err != nil {
+			return fmt.Errorf("could not set watcher on %s objects owned by registered object %q: %w", dr.exposeKey(), name, err)
+		}
+	}
+
+	if dr.formFactor.Autoscaling != nil {
+		if err := c.Watch(source.Kind(dr.cache, &autoscalingv2.HorizontalPodAutoscaler{}), handler.EnqueueRequestForOwner(
+			dr.mgr.GetScheme(),
+			dr.mgr.GetRESTMapper(),
+			owner,
+			handler.OnlyControllerOwner())); err != nil {
+			return fmt.Errorf("could not set watcher on horizontal pod autoscalers owned by registered object %q: %w", name, err)
+		}
+	}
+
+	if dr.formFactor.PodDisruptionBudget != nil {
+		if err := c.Watch(source.Kind(dr.cache, &policyv1.PodDisruptionBudget{}), handler.EnqueueRequestForOwner(
+			dr.mgr.GetScheme(),
+			dr.mgr.GetRESTMapper(),
+			owner,
+			handler.OnlyControllerOwner())); err != nil {
+			return fmt.Errorf("could not set watcher on pod disruption budgets owned by registered object %q: %w", name, err)
+		}
+	}
+
+	if !dr.formFactor.PodDiagnostics {
+		return nil
+	}
+
+	// ReplicaSets and Pods are not owned by the registered object, they are
+	// mapped to it using the labels at the deployment's pod template.
+	if err := c.Watch(source.Kind(dr.cache, &appsv1.ReplicaSet{}),
+		handler.EnqueueRequestsFromMapFunc(dr.mapWorkloadToObject)); err != nil {
+		return fmt.Errorf("could not set watcher on replicasets for registered object %q: %w", name, err)
+	}
+
+	if err := c.Watch(source.Kind(dr.cache, &corev1.Pod{}),
+		handler.EnqueueRequestsFromMapFunc(dr.mapWorkloadToObject)); err != nil {
+		return fmt.Errorf("could not set watcher on pods for registered object %q: %w", name, err)
+	}
+
 	return nil
 }
 
+// mapWorkloadToObject returns the registered object that a workload
+// element labeled by Scoby belongs to.
+func (dr *deploymentReconciler) mapWorkloadToObject(_ context.Context, o client.Object) []reconcile.Request {
+	l := o.GetLabels()
+	if l[resources.AppNameLabel] != dr.name ||
+		l[resources.AppComponentLabel] != reconciler.ComponentWorkload ||
+		l[resources.AppInstanceLabel] == "" {
+		return nil
+	}
+
+	return []reconcile.Request{{NamespacedName: client.ObjectKey{
+		Namespace: o.GetNamespace(),
+		Name:      l[resources.AppInstanceLabel],
+	}}}
+}
+
 func (dr *deploymentReconciler) PreRender(ctx context.Context, obj reconciler.Object) (map[string]*unstructured.Unstructured, error) {
 	dr.log.V(1).Info("pre-rendering object instance", "object", obj)
 
@@ -146,6 +248,45 @@ func (dr *deploymentReconciler) PreRender(ctx context.Context, obj reconciler.Ob
 		}
 
 		pr["service"] = &unstructured.Unstructured{Object: us}
+
+		if dr.serviceOptions.Expose != nil {
+			ue, err := dr.createExposureFromRegistered(obj)
+			if err != nil {
+				return nil, fmt.Errorf("could not render service exposure: %w", err)
+			}
+
+			dr.log.V(5).Info("candidate exposure object", "object", *ue)
+
+			pr[dr.exposeKey()] = ue
+		}
+	}
+
+	if dr.formFactor != nil && dr.formFactor.Autoscaling != nil {
+		hpa, err := dr.createHorizontalPodAutoscalerFromRegistered(obj)
+		if err != nil {
+			return nil, fmt.Errorf("could not render horizontal pod autoscaler object: %w", err)
+		}
+
+		uh, err := runtime.DefaultUnstructuredConverter.ToUnstructured(hpa)
+		if err != nil {
+			return nil, fmt.Errorf("horizontal pod autoscaler from rendered candidates cannot be converted into unstructured: %w", err)
+		}
+
+		pr[horizontalPodAutoscalerKey] = &unstructured.Unstructured{Object: uh}
+	}
+
+	if dr.formFactor != nil && dr.formFactor.PodDisruptionBudget != nil {
+		pdb, err := dr.createPodDisruptionBudgetFromRegistered(obj)
+		if err != nil {
+			return nil, fmt.Errorf("could not render pod disruption budget object: %w", err)
+		}
+
+		up, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pdb)
+		if err != nil {
+			return nil, fmt.Errorf("pod disruption budget from rendered candidates cannot be converted into unstructured: %w", err)
+		}
+
+		pr[podDisruptionBudgetKey] = &unstructured.Unstructured{Object: up}
 	}
 
 	return pr, nil
@@ -155,7 +296,7 @@ func (dr *deploymentReconciler) Reconcile(ctx context.Context, obj reconciler.Ob
 	dr.log.V(1).Info("reconciling object instance", "object", obj)
 
 	od, ok := objects["deployment"]
-	if !ok {
+	if !ok || od == nil {
 		return reconcile.Result{}, fmt.Errorf("could not get deployment from rendered candidates list: %+v", objects)
 	}
 
@@ -170,13 +311,36 @@ func (dr *deploymentReconciler) Reconcile(ctx context.Context, obj reconciler.Ob
 	}
 
 	dr.log.V(1).Info("updating deployment status", "object", obj)
-	dr.updateDeploymentStatus(obj, d)
+	dr.updateDeploymentStatus(ctx, obj, d)
+
+	if err := dr.reconcileScaling(ctx, obj, objects); err != nil {
+		return reconcile.Result{}, err
+	}
 
 	if dr.serviceOptions != nil {
 
 		os, ok := objects["service"]
 		if !ok {
-			return reconcile.Result{}, fmt.Errorf("could not get service from rendered candidates list: %w", err)
+			return reconcile.Result{}, fmt.Errorf("could not get service from rendered candidates list: %+v", objects)
+		}
+
+		// A nil service is the marker used by hooks to omit it. Exposure
+		// is omitted along with the service it routes to.
+		if os == nil {
+			if dr.serviceOptions.Expose != nil {
+				if err := dr.deleteExposure(ctx, obj); err != nil {
+					return reconcile.Result{}, err
+				}
+				dr.updateExposureOmittedStatus(obj)
+			}
+
+			if err := dr.deleteService(ctx, obj); err != nil {
+				return reconcile.Result{}, err
+			}
+
+			dr.log.V(1).Info("updating omitted service status", "object", obj)
+			dr.updateServiceOmittedStatus(obj)
+			return reconcile.Result{}, nil
 		}
 
 		s := &corev1.Service{}
@@ -191,6 +355,12 @@ func (dr *deploymentReconciler) Reconcile(ctx context.Context, obj reconciler.Ob
 
 		dr.log.V(1).Info("updating service status", "object", obj)
 		dr.updateServiceStatus(obj, s)
+
+		if dr.serviceOptions.Expose != nil {
+			if err := dr.reconcileExposure(ctx, obj, objects); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
 	}
 
 	return reconcile.Result{}, nil
@@ -214,16 +384,29 @@ func (dr *deploymentReconciler) reconcileDeployment(ctx context.Context, obj rec
 		// optimistic concurrency, as per Kubernetes API conventions
 		desired.SetResourceVersion(existing.GetResourceVersion())
 
+		// Replicas managed by the autoscaler are kept.
+		if desired.Spec.Replicas == nil {
+			desired.Spec.Replicas = existing.Spec.Replicas
+		}
+
 		if err = dr.client.Update(ctx, desired); err != nil {
+			dr.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeWarning, reconciler.EventReasonChildFailed,
+				"Failed to update Deployment %s: %v", desired.Name, err)
 			return nil, fmt.Errorf("could not update deployment object: %+w", err)
 		}
+		dr.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeNormal, reconciler.EventReasonChildUpdated,
+			"Updated Deployment %s", desired.Name)
 
 	case apierrs.IsNotFound(err):
 		dr.log.Info("creating deployment", "object", desired)
 		dr.log.V(5).Info("desired deployment", "object", *desired)
 		if err = dr.client.Create(ctx, desired); err != nil {
+			dr.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeWarning, reconciler.EventReasonChildFailed,
+				"Failed to create Deployment %s: %v", desired.Name, err)
 			return nil, fmt.Errorf("could not create deployment object: %w", err)
 		}
+		dr.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeNormal, reconciler.EventReasonChildCreated,
+			"Created Deployment %s", desired.Name)
 
 	default:
 		return nil, fmt.Errorf("could not retrieve controlled object %s: %w", client.ObjectKeyFromObject(desired), err)
@@ -232,7 +415,7 @@ func (dr *deploymentReconciler) reconcileDeployment(ctx context.Context, obj rec
 	return desired, nil
 }
 
-func (dr *deploymentReconciler) updateDeploymentStatus(obj reconciler.Object, d *appsv1.Deployment) {
+func (dr *deploymentReconciler) updateDeploymentStatus(ctx context.Context, obj reconciler.Object, d *appsv1.Deployment) {
 	dr.log.V(1).Info("updating deployment status", "object", obj)
 
 	desired := &commonv1alpha1.Condition{
@@ -265,39 +448,86 @@ func (dr *deploymentReconciler) updateDeploymentStatus(obj reconciler.Object, d
 		}
 	}
 
+	if desired.Status != metav1.ConditionTrue && dr.formFactor != nil && dr.formFactor.PodDiagnostics {
+		if f := dr.diagnose(ctx, obj); f != nil {
+			desired.Status = metav1.ConditionFalse
+			desired.Reason = f.Reason
+			desired.Message = f.Message
+		}
+	}
+
 	obj.GetStatusManager().SetCondition(desired)
 }
 
+// diagnose looks for failures at the ReplicaSets and Pods that
+// belong to the object's deployment.
+func (dr *deploymentReconciler) diagnose(ctx context.Context, obj reconciler.Object) *diagnostics.Failure {
+	opts := []client.ListOption{
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingLabels{
+			resources.AppNameLabel:      dr.name,
+			resources.AppInstanceLabel:  obj.GetName(),
+			resources.AppComponentLabel: reconciler.ComponentWorkload,
+		},
+	}
+
+	rsl := &appsv1.ReplicaSetList{}
+	if err := dr.client.List(ctx, rsl, opts...); err != nil {
+		dr.log.Error(err, "could not list replicasets for diagnostics", "object", obj)
+	} else if f := diagnostics.FromReplicaSets(rsl.Items); f != nil {
+		return f
+	}
+
+	pl := &corev1.PodList{}
+	if err := dr.client.List(ctx, pl, opts...); err != nil {
+		dr.log.Error(err, "could not list pods for diagnostics", "object", obj)
+		return nil
+	}
+
+	return diagnostics.FromPods(pl.Items)
+}
+
 func (dr *deploymentReconciler) createDeploymentFromRegistered(obj reconciler.Object) (*appsv1.Deployment, error) {
-	replicas := defaultReplicas
-	if dr.formFactor != nil {
-		replicas = dr.formFactor.Replicas
+	copts := obj.AsContainerOptions()
+	if dr.serviceOptions != nil {
+		// Declare the ports targeted by the service unless already
+		// declared at the container settings.
+		for _, p := range dr.serviceOptions.GetPorts() {
+			copts = append(copts, resources.ContainerAddPorts(corev1.ContainerPort{
+				ContainerPort: p.GetTargetPort(),
+				Protocol:      p.Protocol,
+			}))
+		}
 	}
 
-	pso := append(obj.AsPodSpecOptions(), resources.PodSpecAddContainer(
+	// The workload container is added first, before any sidecar.
+	pso := append([]resources.PodSpecOption{resources.PodSpecAddContainer(
 		resources.NewContainer(
 			reconciler.DefaultContainerName,
-			dr.fromImage.Repo,
-			obj.AsContainerOptions()...,
-		)))
-
-	return resources.NewDeployment(obj.GetNamespace(), dr.name+"-"+obj.GetName(),
-		resources.DeploymentWithMetaOptions(
-			resources.MetaAddLabel(resources.AppNameLabel, dr.name),
-			resources.MetaAddLabel(resources.AppInstanceLabel, obj.GetName()),
-			resources.MetaAddLabel(resources.AppComponentLabel, reconciler.ComponentWorkload),
-			resources.MetaAddLabel(resources.AppPartOfLabel, reconciler.PartOf),
-			resources.MetaAddLabel(resources.AppManagedByLabel, reconciler.ManagedBy),
-
-			resources.MetaAddOwner(obj, obj.GetObjectKind().GroupVersionKind()),
-		),
-		resources.DeploymentSetReplicas(int32(replicas)),
+			dr.fromImage.Image(),
+			copts...,
+		))},
+		obj.AsPodSpecOptions()...)
+
+	dopts := []resources.DeploymentOption{
+		resources.DeploymentWithMetaOptions(dr.metaOptions(obj)...),
 		resources.DeploymentAddSelectorForTemplate(resources.AppNameLabel, dr.name),
 		resources.DeploymentAddSelectorForTemplate(resources.AppInstanceLabel, obj.GetName()),
 		resources.DeploymentAddSelectorForTemplate(resources.AppComponentLabel, reconciler.ComponentWorkload),
 
 		resources.DeploymentWithTemplateSpecOptions(
-			resources.PodTemplateSpecWithPodSpecOptions(pso...))), nil
+			resources.PodTemplateSpecWithPodSpecOptions(pso...)),
+	}
+
+	// Replicas are not set when managed by the autoscaler.
+	switch {
+	case dr.formFactor == nil:
+		dopts = append(dopts, resources.DeploymentSetReplicas(defaultReplicas))
+	case dr.formFactor.Autoscaling == nil:
+		dopts = append(dopts, resources.DeploymentSetReplicas(int32(dr.formFactor.Replicas)))
+	}
+
+	return resources.NewDeployment(obj.GetNamespace(), dr.name+"-"+obj.GetName(), dopts...), nil
 }
 
 func (dr *deploymentReconciler) reconcileService(ctx context.Context, obj reconciler.Object, desired *corev1.Service) (*corev1.Service, error) {
@@ -305,6 +535,16 @@ func (dr *deploymentReconciler) reconcileService(ctx context.Context, obj reconc
 
 	existing := &corev1.Service{}
 	err := dr.client.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if err == nil && (desired.Spec.ClusterIP == corev1.ClusterIPNone) != (existing.Spec.ClusterIP == corev1.ClusterIPNone) {
+		// The cluster IP is immutable, switching from or to a headless
+		// service requires creating it again.
+		dr.log.Info("deleting service to switch headless mode", "object", existing)
+		if err := dr.client.Delete(ctx, existing); err != nil && !apierrs.IsNotFound(err) {
+			return nil, fmt.Errorf("could not delete service object: %w", err)
+		}
+		err = apierrs.NewNotFound(corev1.Resource("services"), desired.Name)
+	}
+
 	switch {
 	case err == nil:
 		if semantic.Semantic.DeepEqual(desired, existing) {
@@ -319,15 +559,23 @@ func (dr *deploymentReconciler) reconcileService(ctx context.Context, obj reconc
 		desired.SetResourceVersion(existing.GetResourceVersion())
 
 		if err = dr.client.Update(ctx, desired); err != nil {
+			dr.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeWarning, reconciler.EventReasonChildFailed,
+				"Failed to update Service %s: %v", desired.Name, err)
 			return nil, fmt.Errorf("could not update service object: %+w", err)
 		}
+		dr.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeNormal, reconciler.EventReasonChildUpdated,
+			"Updated Service %s", desired.Name)
 
 	case apierrs.IsNotFound(err):
 		dr.log.Info("creating service", "object", desired)
 		dr.log.V(5).Info("desired service", "object", *desired)
 		if err = dr.client.Create(ctx, desired); err != nil {
+			dr.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeWarning, reconciler.EventReasonChildFailed,
+				"Failed to create Service %s: %v", desired.Name, err)
 			return nil, fmt.Errorf("could not create service object: %w", err)
 		}
+		dr.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeNormal, reconciler.EventReasonChildCreated,
+			"Created Service %s", desired.Name)
 	default:
 		return nil, fmt.Errorf("could not retrieve controlled service %s: %w", client.ObjectKeyFromObject(desired), err)
 	}
@@ -335,6 +583,45 @@ func (dr *deploymentReconciler) reconcileService(ctx context.Context, obj reconc
 	return desired, nil
 }
 
+// deleteService removes the service created for the object, if any.
+func (dr *deploymentReconciler) deleteService(ctx context.Context, obj reconciler.Object) error {
+	existing := &corev1.Service{}
+	err := dr.client.Get(ctx, client.ObjectKey{Namespace: obj.GetNamespace(), Name: dr.name + "-" + obj.GetName()}, existing)
+	switch {
+	case apierrs.IsNotFound(err):
+		return nil
+	case err != nil:
+		return fmt.Errorf("could not retrieve controlled service %s: %w", client.ObjectKeyFromObject(existing), err)
+	}
+
+	if !metav1.IsControlledBy(existing, obj) {
+		return nil
+	}
+
+	dr.log.Info("deleting omitted service", "object", existing)
+	if err := dr.client.Delete(ctx, existing); err != nil && !apierrs.IsNotFound(err) {
+		dr.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeWarning, reconciler.EventReasonChildFailed,
+			"Failed to delete Service %s: %v", existing.Name, err)
+		return fmt.Errorf("could not delete service object: %w", err)
+	}
+	dr.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeNormal, reconciler.EventReasonChildDeleted,
+		"Deleted Service %s", existing.Name)
+
+	return nil
+}
+
+func (dr *deploymentReconciler) updateServiceOmittedStatus(obj reconciler.Object) {
+	sm := obj.GetStatusManager()
+	sm.SetAddressURL("")
+	sm.SetCondition(&commonv1alpha1.Condition{
+		Type:               ConditionTypeServiceReady,
+		Reason:             "ServiceOmitted",
+		Status:             metav1.ConditionTrue,
+		Message:            "Service omitted by hook",
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
 func (dr *deploymentReconciler) updateServiceStatus(obj reconciler.Object, s *corev1.Service) {
 	dr.log.V(1).Info("updating service status", "object", obj)
 
@@ -351,7 +638,7 @@ func (dr *deploymentReconciler) updateServiceStatus(obj reconciler.Object, s *co
 		desired.Reason = "ServiceDoesNotExist"
 
 	} else {
-		address = fmt.Sprintf("http://%s.%s.svc.%s", s.Name, s.Namespace, resolver.ClusterDomain)
+		address = dr.serviceAddress(s)
 	}
 
 	sm := obj.GetStatusManager()
@@ -360,18 +647,107 @@ func (dr *deploymentReconciler) updateServiceStatus(obj reconciler.Object, s *co
 }
 
 func (dr *deploymentReconciler) createServiceFromRegistered(obj reconciler.Object) (*corev1.Service, error) {
-	return resources.NewService(obj.GetNamespace(), dr.name+"-"+obj.GetName(),
-		resources.ServiceWithMetaOptions(
-			resources.MetaAddLabel(resources.AppNameLabel, dr.name),
-			resources.MetaAddLabel(resources.AppInstanceLabel, obj.GetName()),
-			resources.MetaAddLabel(resources.AppComponentLabel, reconciler.ComponentWorkload),
-			resources.MetaAddLabel(resources.AppPartOfLabel, reconciler.PartOf),
-			resources.MetaAddLabel(resources.AppManagedByLabel, reconciler.ManagedBy),
-			resources.MetaAddOwner(obj, obj.GetObjectKind().GroupVersionKind()),
-		),
+	so := dr.serviceOptions
+	if so.Headless && so.Type != "" && so.Type != corev1.ServiceTypeClusterIP {
+		return nil, fmt.Errorf("headless services must be of type %s", corev1.ServiceTypeClusterIP)
+	}
+
+	ports := so.GetPorts()
+	names := make(map[string]struct{}, len(ports))
+	sps := make([]corev1.ServicePort, 0, len(ports))
+	for _, p := range ports {
+		if p.Port <= 0 {
+			if len(so.Ports) == 0 {
+				return nil, fmt.Errorf("service requires either port or ports to be informed")
+			}
+			return nil, fmt.Errorf("service port %q must be greater than 0", p.Name)
+		}
+		if len(ports) > 1 && p.Name == "" {
+			return nil, fmt.Errorf("service ports must be named when exposing multiple ports")
+		}
+		if _, ok := names[p.Name]; ok {
+			return nil, fmt.Errorf("service port name %q is duplicated", p.Name)
+		}
+		names[p.Name] = struct{}{}
+
+		sps = append(sps, corev1.ServicePort{
+			Name:       p.Name,
+			Port:       p.Port,
+			TargetPort: intstr.FromInt(int(p.GetTargetPort())),
+			Protocol:   p.Protocol,
+			NodePort:   p.NodePort,
+		})
+	}
+
+	if a := so.Address; a != nil && a.Port != "" {
+		if _, ok := names[a.Port]; !ok {
+			return nil, fmt.Errorf("address port %q is not exposed at the service", a.Port)
+		}
+	}
+
+	if ex := so.Expose; ex != nil && ex.Port != "" {
+		if _, ok := names[ex.Port]; !ok {
+			return nil, fmt.Errorf("expose port %q is not exposed at the service", ex.Port)
+		}
+	}
+
+	opts := []resources.ServiceOption{
+		resources.ServiceWithMetaOptions(dr.metaOptions(obj)...),
 		resources.ServiceAddSelectorLabel(resources.AppNameLabel, dr.name),
 		resources.ServiceAddSelectorLabel(resources.AppInstanceLabel, obj.GetName()),
 		resources.ServiceAddSelectorLabel(resources.AppComponentLabel, reconciler.ComponentWorkload),
-		resources.ServiceAddPort("", dr.serviceOptions.Port, dr.serviceOptions.TargetPort),
-	), nil
+		resources.ServiceAddPorts(sps...),
+	}
+
+	if so.Type != "" {
+		opts = append(opts, resources.ServiceSetType(so.Type))
+	}
+	if so.Headless {
+		opts = append(opts, resources.ServiceSetClusterIP(corev1.ClusterIPNone))
+	}
+
+	return resources.NewService(obj.GetNamespace(), dr.name+"-"+obj.GetName(), opts...), nil
+}
+
+// serviceAddress returns the URL for the service using the address port
+// and scheme. The port is omitted when it is the default for the scheme.
+// Headless services resolve to the pods, the target port is used.
+func (dr *deploymentReconciler) serviceAddress(s *corev1.Service) string {
+	scheme := "http"
+	portName := ""
+	if a := dr.serviceOptions.Address; a != nil {
+		if a.Scheme != "" {
+			scheme = a.Scheme
+		}
+		portName = a.Port
+	}
+
+	p := dr.servicePort(portName)
+	port := p.Port
+	if dr.serviceOptions.Headless {
+		port = p.GetTargetPort()
+	}
+
+	host := fmt.Sprintf("%s.%s.svc.%s", s.Name, s.Namespace, resolver.ClusterDomain)
+	switch {
+	case scheme == "http" && port == 80,
+		scheme == "https" && port == 443,
+		port == 0:
+	default:
+		host += ":" + strconv.Itoa(int(port))
+	}
+
+	return scheme + "://" + host
+}
+
+// servicePort returns the service port with the name, or the first port
+// when not found.
+func (dr *deploymentReconciler) servicePort(name string) commonv1alpha1.DeploymentServicePort {
+	ports := dr.serviceOptions.GetPorts()
+	for i := range ports {
+		if ports[i].Name == name {
+			return ports[i]
+		}
+	}
+	return ports[0]
 }
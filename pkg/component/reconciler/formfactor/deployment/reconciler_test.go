@@ -0,0 +1,186 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package deployment
+
+import (
+	"context"
+	"testing"
+
+	tlogr "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	commonv1alpha1 "github.com/triggermesh/scoby/pkg/apis/common/v1alpha1"
+	"github.com/triggermesh/scoby/pkg/component/reconciler"
+	basecrd "github.com/triggermesh/scoby/pkg/component/reconciler/base/crd"
+	baseobject "github.com/triggermesh/scoby/pkg/component/reconciler/base/object"
+	baserenderer "github.com/triggermesh/scoby/pkg/component/reconciler/base/renderer"
+	basestatus "github.com/triggermesh/scoby/pkg/component/reconciler/base/status"
+	"github.com/triggermesh/scoby/pkg/utils/configmap"
+	"github.com/triggermesh/scoby/pkg/utils/resolver"
+
+	. "github.com/triggermesh/scoby/test"
+)
+
+const (
+	tScobyNamespace = "triggermesh"
+
+	tCRD = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: kuards.extensions.triggermesh.io
+spec:
+  group: extensions.triggermesh.io
+  scope: Namespaced
+  names:
+    plural: kuards
+    singular: kuard
+    kind: Kuard
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      subresources:
+        status: {}
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            spec:
+              type: object
+              properties:
+                variable1:
+                  type: string
+            status:
+              type: object
+              properties:
+                url:
+                  type: string
+                conditions:
+                  type: array
+                  items:
+                    type: object
+                    properties:
+                      type:
+                        type: string
+                      status:
+                        type: string
+                      reason:
+                        type: string
+                      message:
+                        type: string
+`
+
+	tInstance = `
+apiVersion: extensions.triggermesh.io/v1
+kind: Kuard
+metadata:
+  name: my-kuard
+  namespace: test
+  uid: 6a7c3d1e-4b2f-4a52-9d4c-2f1e0b7a9c11
+  generation: 1
+spec:
+  variable1: value 1
+`
+)
+
+var tGVK = &schema.GroupVersionKind{
+	Group:   "extensions.triggermesh.io",
+	Version: "v1",
+	Kind:    "Kuard",
+}
+
+// newTestObject returns a rendered instance of the test CRD.
+func newTestObject(t *testing.T, conditions ...string) reconciler.Object {
+	crdv := basecrd.CRDPrioritizedVersion(ReadCRD(tCRD))
+
+	c := fake.NewClientBuilder().Build()
+	r, err := baserenderer.NewRenderer(&commonv1alpha1.Workload{}, resolver.New(c),
+		configmap.NewNamespacedReader(tScobyNamespace, c))
+	require.NoError(t, err)
+
+	smf := basestatus.NewStatusManagerFactory(crdv, reconciler.ConditionTypeReady, conditions, tlogr.NewTestLogger(t))
+	obj := baseobject.NewManager(tGVK, r, smf).NewObject()
+	require.NoError(t, yaml.Unmarshal([]byte(tInstance), obj.AsKubeObject().(*unstructured.Unstructured)))
+	require.NoError(t, r.Render(context.Background(), obj))
+
+	return obj
+}
+
+// newTestReconciler returns a deployment reconciler for the form factor
+// that manages the objects using a fake client.
+func newTestReconciler(t *testing.T, ff *commonv1alpha1.DeploymentFormFactor, objects ...client.Object) *deploymentReconciler {
+	c := fake.NewClientBuilder().WithObjects(objects...).Build()
+	dr := &deploymentReconciler{
+		name:       "kuard",
+		formFactor: ff,
+		fromImage:  &commonv1alpha1.RegistrationFromImage{Repo: "registry.example.com/kuard:v1"},
+		client:     c,
+		apiReader:  c,
+		recorder:   record.NewFakeRecorder(10),
+		log:        tlogr.NewTestLogger(t),
+	}
+
+	if ff != nil && ff.Service != nil {
+		dr.serviceOptions = ff.Service
+	}
+
+	return dr
+}
+
+func TestPreRenderServicePorts(t *testing.T) {
+	testCases := map[string]struct {
+		service     *commonv1alpha1.DeploymentService
+		expectError string
+	}{
+		"port": {
+			service: &commonv1alpha1.DeploymentService{Port: 80, TargetPort: 8080},
+		},
+		"ports": {
+			service: &commonv1alpha1.DeploymentService{Ports: []commonv1alpha1.DeploymentServicePort{
+				{Name: "http", Port: 80},
+				{Name: "metrics", Port: 9090},
+			}},
+		},
+		"no port": {
+			service:     &commonv1alpha1.DeploymentService{},
+			expectError: "could not render service object: service requires either port or ports to be informed",
+		},
+		"only target port": {
+			service:     &commonv1alpha1.DeploymentService{TargetPort: 8080},
+			expectError: "could not render service object: service requires either port or ports to be informed",
+		},
+		"ports entry without port": {
+			service: &commonv1alpha1.DeploymentService{Ports: []commonv1alpha1.DeploymentServicePort{
+				{Name: "http", Port: 80},
+				{Name: "metrics", TargetPort: 9090},
+			}},
+			expectError: `could not render service object: service port "metrics" must be greater than 0`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			dr := newTestReconciler(t, &commonv1alpha1.DeploymentFormFactor{Service: tc.service})
+
+			candidates, err := dr.PreRender(context.Background(), newTestObject(t))
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Contains(t, candidates, "service")
+		})
+	}
+}
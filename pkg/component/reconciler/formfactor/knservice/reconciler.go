@@ -5,8 +5,13 @@ package knservice
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
+	"text/template"
 
 	"github.com/go-logr/logr"
 
@@ -15,8 +20,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -25,11 +32,13 @@ import (
 
 	"knative.dev/networking/pkg/apis/networking"
 	"knative.dev/serving/pkg/apis/autoscaling"
+	"knative.dev/serving/pkg/apis/serving"
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
 
 	commonv1alpha1 "github.com/triggermesh/scoby/pkg/apis/common/v1alpha1"
 	hookv1 "github.com/triggermesh/scoby/pkg/apis/hook/v1"
 	"github.com/triggermesh/scoby/pkg/component/reconciler"
+	"github.com/triggermesh/scoby/pkg/utils/diagnostics"
 	"github.com/triggermesh/scoby/pkg/utils/resources"
 	"github.com/triggermesh/scoby/pkg/utils/semantic"
 )
@@ -39,18 +48,30 @@ const (
 
 	ConditionReasonKnativeServiceReady   = "KNSERVICEOK"
 	ConditionReasonKnativeServiceUnknown = "KNSERVICEUNKOWN"
+
+	// Number of hexadecimal characters of the revision template hash.
+	revisionHashLength = 10
 )
 
-func New(name string, wkl *commonv1alpha1.Workload, mgr ctrl.Manager) reconciler.FormFactorReconciler {
+func New(name string, wkl *commonv1alpha1.Workload, mgr ctrl.Manager, recorder record.EventRecorder, opts ...reconciler.FormFactorOption) reconciler.FormFactorReconciler {
+	o := &reconciler.FormFactorOptions{
+		Cache:  mgr.GetCache(),
+		Client: mgr.GetClient(),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
 
 	sr := &knserviceReconciler{
 		name:       name,
 		formFactor: wkl.FormFactor.KnativeService,
 		fromImage:  &wkl.FromImage,
 
-		mgr:    mgr,
-		client: mgr.GetClient(),
-		log:    mgr.GetLogger(),
+		mgr:      mgr,
+		cache:    o.Cache,
+		client:   o.Client,
+		recorder: recorder,
+		log:      mgr.GetLogger(),
 		info: &hookv1.FormFactorInfo{
 			Name: "ksvc",
 		},
@@ -64,10 +85,12 @@ type knserviceReconciler struct {
 	formFactor *commonv1alpha1.KnativeServiceFormFactor
 	fromImage  *commonv1alpha1.RegistrationFromImage
 
-	mgr    ctrl.Manager
-	client client.Client
-	log    logr.Logger
-	info   *hookv1.FormFactorInfo
+	mgr      ctrl.Manager
+	cache    cache.Cache
+	client   client.Client
+	recorder record.EventRecorder
+	log      logr.Logger
+	info     *hookv1.FormFactorInfo
 }
 
 var _ reconciler.FormFactorReconciler = (*knserviceReconciler)(nil)
@@ -84,7 +107,7 @@ func (sr *knserviceReconciler) GetInfo() *hookv1.FormFactorInfo {
 }
 
 func (sr *knserviceReconciler) SetupController(name string, c controller.Controller, owner client.Object) error {
-	if err := c.Watch(source.Kind(sr.mgr.GetCache(), resources.NewKnativeService("", "")),
+	if err := c.Watch(source.Kind(sr.cache, resources.NewKnativeService("", "")),
 		handler.EnqueueRequestForOwner(
 			sr.mgr.GetScheme(),
 			sr.mgr.GetRESTMapper(),
@@ -93,9 +116,35 @@ func (sr *knserviceReconciler) SetupController(name string, c controller.Control
 		return fmt.Errorf("could not set watcher on knative services owned by registered object %q: %w", name, err)
 	}
 
+	if sr.formFactor == nil || !sr.formFactor.PodDiagnostics {
+		return nil
+	}
+
+	// Pods are not owned by the registered object, they are mapped to it
+	// using the label that Knative sets with the service name.
+	if err := c.Watch(source.Kind(sr.cache, &corev1.Pod{}),
+		handler.EnqueueRequestsFromMapFunc(sr.mapPodToObject)); err != nil {
+		return fmt.Errorf("could not set watcher on pods for registered object %q: %w", name, err)
+	}
+
 	return nil
 }
 
+// mapPodToObject returns the registered object that a knative
+// service pod belongs to.
+func (sr *knserviceReconciler) mapPodToObject(_ context.Context, o client.Object) []reconcile.Request {
+	ksvc := o.GetLabels()[serving.ServiceLabelKey]
+	name := strings.TrimPrefix(ksvc, sr.name+"-")
+	if name == ksvc || name == "" {
+		return nil
+	}
+
+	return []reconcile.Request{{NamespacedName: client.ObjectKey{
+		Namespace: o.GetNamespace(),
+		Name:      name,
+	}}}
+}
+
 func (sr *knserviceReconciler) PreRender(ctx context.Context, obj reconciler.Object) (map[string]*unstructured.Unstructured, error) {
 	sr.log.V(1).Info("pre-rendering object instance", "object", obj)
 
@@ -120,7 +169,7 @@ func (sr *knserviceReconciler) Reconcile(ctx context.Context, obj reconciler.Obj
 	sr.log.V(1).Info("reconciling object instance", "object", obj)
 
 	oksvc, ok := objects["ksvc"]
-	if !ok {
+	if !ok || oksvc == nil {
 		return reconcile.Result{}, fmt.Errorf("could not get knative service from rendered candidates list: %+v", objects)
 	}
 
@@ -129,12 +178,16 @@ func (sr *knserviceReconciler) Reconcile(ctx context.Context, obj reconciler.Obj
 		return reconcile.Result{}, fmt.Errorf("knative service from rendered candidates is not a knative service object: %w", err)
 	}
 
+	if err := sr.setRevisionName(obj, ksvc); err != nil {
+		return reconcile.Result{}, fmt.Errorf("could not set knative service revision name: %w", err)
+	}
+
 	ksvc, err := sr.reconcileKnativeService(ctx, obj, ksvc)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	sr.updateKnativeServiceStatus(obj, ksvc)
+	sr.updateKnativeServiceStatus(ctx, obj, ksvc)
 
 	return reconcile.Result{}, nil
 }
@@ -158,15 +211,23 @@ func (sr *knserviceReconciler) reconcileKnativeService(ctx context.Context, obj
 		desired.SetResourceVersion(existing.GetResourceVersion())
 
 		if err = sr.client.Update(ctx, desired); err != nil {
+			sr.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeWarning, reconciler.EventReasonChildFailed,
+				"Failed to update Knative Service %s: %v", desired.Name, err)
 			return nil, fmt.Errorf("could not update knative service object: %+w", err)
 		}
+		sr.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeNormal, reconciler.EventReasonChildUpdated,
+			"Updated Knative Service %s", desired.Name)
 
 	case apierrs.IsNotFound(err):
 		sr.log.Info("creating knative service", "object", desired)
 		sr.log.V(5).Info("desired knative service", "object", *desired)
 		if err = sr.client.Create(ctx, desired); err != nil {
+			sr.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeWarning, reconciler.EventReasonChildFailed,
+				"Failed to create Knative Service %s: %v", desired.Name, err)
 			return nil, fmt.Errorf("could not create knative service object: %w", err)
 		}
+		sr.recorder.Eventf(obj.AsKubeObject(), corev1.EventTypeNormal, reconciler.EventReasonChildCreated,
+			"Created Knative Service %s", desired.Name)
 
 	default:
 		return nil, fmt.Errorf("could not retrieve controlled object %s: %w", client.ObjectKeyFromObject(desired), err)
@@ -175,7 +236,7 @@ func (sr *knserviceReconciler) reconcileKnativeService(ctx context.Context, obj
 	return desired, nil
 }
 
-func (sr *knserviceReconciler) updateKnativeServiceStatus(obj reconciler.Object, ksvc *servingv1.Service) {
+func (sr *knserviceReconciler) updateKnativeServiceStatus(ctx context.Context, obj reconciler.Object, ksvc *servingv1.Service) {
 	sr.log.V(1).Info("updating knativeService status", "object", obj)
 
 	desired := &commonv1alpha1.Condition{
@@ -211,6 +272,14 @@ func (sr *knserviceReconciler) updateKnativeServiceStatus(obj reconciler.Object,
 		if ksvc.Status.Address != nil {
 			address = ksvc.Status.Address.URL.String()
 		}
+
+		if desired.Status != metav1.ConditionTrue && sr.formFactor != nil && sr.formFactor.PodDiagnostics {
+			if f := sr.diagnose(ctx, ksvc); f != nil {
+				desired.Status = metav1.ConditionFalse
+				desired.Reason = f.Reason
+				desired.Message = f.Message
+			}
+		}
 	}
 
 	sm := obj.GetStatusManager()
@@ -218,6 +287,41 @@ func (sr *knserviceReconciler) updateKnativeServiceStatus(obj reconciler.Object,
 	sm.SetCondition(desired)
 }
 
+// diagnose looks for failures at the latest created revision of the
+// knative service and its pods.
+func (sr *knserviceReconciler) diagnose(ctx context.Context, ksvc *servingv1.Service) *diagnostics.Failure {
+	name := ksvc.Status.LatestCreatedRevisionName
+	if name == "" {
+		return nil
+	}
+
+	pl := &corev1.PodList{}
+	if err := sr.client.List(ctx, pl,
+		client.InNamespace(ksvc.Namespace),
+		client.MatchingLabels{serving.RevisionLabelKey: name}); err != nil {
+		sr.log.Error(err, "could not list pods for diagnostics", "revision", name)
+	} else if f := diagnostics.FromPods(pl.Items); f != nil {
+		return f
+	}
+
+	rev := &servingv1.Revision{}
+	if err := sr.client.Get(ctx, client.ObjectKey{Namespace: ksvc.Namespace, Name: name}, rev); err != nil {
+		sr.log.Error(err, "could not retrieve revision for diagnostics", "revision", name)
+		return nil
+	}
+
+	for _, c := range rev.Status.Conditions {
+		if c.Type != servingv1.RevisionConditionReady && c.Status == corev1.ConditionFalse {
+			return &diagnostics.Failure{
+				Reason:  c.Reason,
+				Message: fmt.Sprintf("revision %s: %s", name, c.Message),
+			}
+		}
+	}
+
+	return nil
+}
+
 func (sr *knserviceReconciler) createKnServiceFromRegistered(obj reconciler.Object) (*servingv1.Service, error) {
 	metaopts := []resources.MetaOption{
 		resources.MetaAddLabel(resources.AppNameLabel, sr.name),
@@ -229,12 +333,14 @@ func (sr *knserviceReconciler) createKnServiceFromRegistered(obj reconciler.Obje
 		resources.MetaAddOwner(obj, obj.GetObjectKind().GroupVersionKind()),
 	}
 
-	pso := append(obj.AsPodSpecOptions(), resources.PodSpecAddContainer(
+	// The workload container is added first, before any sidecar.
+	pso := append([]resources.PodSpecOption{resources.PodSpecAddContainer(
 		resources.NewContainer(
 			reconciler.DefaultContainerName,
-			sr.fromImage.Repo,
+			sr.fromImage.Image(),
 			obj.AsContainerOptions()...,
-		)))
+		))},
+		obj.AsPodSpecOptions()...)
 
 	revspecopts := []resources.RevisionTemplateOption{
 		resources.RevisionSpecWithPodSpecOptions(pso...),
@@ -254,9 +360,160 @@ func (sr *knserviceReconciler) createKnServiceFromRegistered(obj reconciler.Obje
 			revspecopts = append(revspecopts, resources.RevisionWithMetaOptions(
 				resources.MetaAddAnnotation(autoscaling.MaxScaleAnnotationKey, strconv.Itoa(*sr.formFactor.MaxScale))))
 		}
+
+		revspecopts = append(revspecopts, sr.revisionOptions()...)
 	}
 
-	return resources.NewKnativeService(obj.GetNamespace(), sr.name+"-"+obj.GetName(),
+	ksvcopts := append([]resources.KnativeServiceOption{
 		resources.KnativeServiceWithMetaOptions(metaopts...),
-		resources.KnativeServiceWithRevisionOptions(revspecopts...)), nil
+		resources.KnativeServiceWithRevisionOptions(revspecopts...),
+	}, sr.trafficOptions()...)
+
+	return resources.NewKnativeService(obj.GetNamespace(), sr.name+"-"+obj.GetName(), ksvcopts...), nil
+}
+
+// setRevisionName informs the revision name at the Knative Service that is
+// about to be reconciled. The name includes the template hash, it is set on
+// the final candidate so that changes made by hooks are taken into account.
+func (sr *knserviceReconciler) setRevisionName(obj reconciler.Object, ksvc *servingv1.Service) error {
+	if sr.formFactor == nil || sr.formFactor.RevisionName == "" {
+		return nil
+	}
+
+	hash, err := revisionHash(&ksvc.Spec.Template)
+	if err != nil {
+		return err
+	}
+
+	rn, err := sr.revisionName(obj, hash)
+	if err != nil {
+		return err
+	}
+	resources.RevisionWithName(rn)(&ksvc.Spec.Template)
+
+	return nil
+}
+
+// revisionHash returns a short hash of the revision template, not including
+// its name. Knative rejects revision template changes that keep the revision
+// name, the hash changes along with any rendered input, including those that
+// do not bump the instance generation like resolved or ConfigMap sourced
+// values.
+func revisionHash(rts *servingv1.RevisionTemplateSpec) (string, error) {
+	rts = rts.DeepCopy()
+	rts.Name = ""
+
+	b, err := json.Marshal(rts)
+	if err != nil {
+		return "", fmt.Errorf("could not serialize revision template: %w", err)
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])[:revisionHashLength], nil
+}
+
+// revisionOptions returns the revision autoscaling, concurrency and
+// timeout options.
+func (sr *knserviceReconciler) revisionOptions() []resources.RevisionTemplateOption {
+	ff := sr.formFactor
+	annotations := map[string]string{}
+
+	if ff.InitialScale != nil {
+		annotations[autoscaling.InitialScaleAnnotationKey] = strconv.Itoa(*ff.InitialScale)
+	}
+	if ff.ScaleDownDelay != nil {
+		annotations[autoscaling.ScaleDownDelayAnnotationKey] = *ff.ScaleDownDelay
+	}
+	if as := ff.Autoscaling; as != nil {
+		if as.Class != "" {
+			annotations[autoscaling.ClassAnnotationKey] = as.Class
+		}
+		if as.Metric != "" {
+			annotations[autoscaling.MetricAnnotationKey] = as.Metric
+		}
+		if as.Target != nil {
+			annotations[autoscaling.TargetAnnotationKey] = strconv.Itoa(*as.Target)
+		}
+	}
+
+	opts := []resources.RevisionTemplateOption{}
+	for k, v := range annotations {
+		opts = append(opts, resources.RevisionWithMetaOptions(resources.MetaAddAnnotation(k, v)))
+	}
+
+	if ff.ContainerConcurrency != nil {
+		opts = append(opts, resources.RevisionSpecWithContainerConcurrency(*ff.ContainerConcurrency))
+	}
+	if ff.TimeoutSeconds != nil {
+		opts = append(opts, resources.RevisionSpecWithTimeoutSeconds(*ff.TimeoutSeconds))
+	}
+	if ff.ResponseStartTimeoutSeconds != nil {
+		opts = append(opts, resources.RevisionSpecWithResponseStartTimeoutSeconds(*ff.ResponseStartTimeoutSeconds))
+	}
+
+	return opts
+}
+
+// revisionName executes the revision name template for the object, which
+// must include the revision template hash.
+func (sr *knserviceReconciler) revisionName(obj reconciler.Object, hash string) (string, error) {
+	t, err := template.New("revisionName").Option("missingkey=error").Parse(sr.formFactor.RevisionName)
+	if err != nil {
+		return "", fmt.Errorf("could not parse revision name template: %w", err)
+	}
+
+	serviceName := sr.name + "-" + obj.GetName()
+	b := &strings.Builder{}
+	if err := t.Execute(b, struct {
+		ServiceName string
+		Name        string
+		Namespace   string
+		Generation  int64
+		Hash        string
+	}{
+		ServiceName: serviceName,
+		Name:        obj.GetName(),
+		Namespace:   obj.GetNamespace(),
+		Generation:  obj.GetGeneration(),
+		Hash:        hash,
+	}); err != nil {
+		return "", fmt.Errorf("could not execute revision name template: %w", err)
+	}
+
+	name := b.String()
+	if !strings.HasPrefix(name, serviceName+"-") {
+		return "", fmt.Errorf("revision name %q must be prefixed by the service name %q", name, serviceName)
+	}
+
+	if !strings.Contains(name, hash) {
+		return "", fmt.Errorf("revision name %q must include the revision template hash using {{.Hash}}", name)
+	}
+
+	return name, nil
+}
+
+// trafficOptions returns the traffic targets, which default to sending
+// all traffic to the latest revision.
+func (sr *knserviceReconciler) trafficOptions() []resources.KnativeServiceOption {
+	if sr.formFactor == nil || len(sr.formFactor.Traffic) == 0 {
+		latest, percent := true, int64(100)
+		return []resources.KnativeServiceOption{
+			resources.KnativeServiceAddTrafficTarget(servingv1.TrafficTarget{
+				LatestRevision: &latest,
+				Percent:        &percent,
+			}),
+		}
+	}
+
+	opts := make([]resources.KnativeServiceOption, 0, len(sr.formFactor.Traffic))
+	for _, t := range sr.formFactor.Traffic {
+		opts = append(opts, resources.KnativeServiceAddTrafficTarget(servingv1.TrafficTarget{
+			RevisionName:   t.RevisionName,
+			LatestRevision: t.LatestRevision,
+			Percent:        t.Percent,
+			Tag:            t.Tag,
+		}))
+	}
+
+	return opts
 }
@@ -0,0 +1,171 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package knservice
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tlogr "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+
+	commonv1alpha1 "github.com/triggermesh/scoby/pkg/apis/common/v1alpha1"
+	basecrd "github.com/triggermesh/scoby/pkg/component/reconciler/base/crd"
+	baseobject "github.com/triggermesh/scoby/pkg/component/reconciler/base/object"
+	baserenderer "github.com/triggermesh/scoby/pkg/component/reconciler/base/renderer"
+	basestatus "github.com/triggermesh/scoby/pkg/component/reconciler/base/status"
+	"github.com/triggermesh/scoby/pkg/utils/configmap"
+	"github.com/triggermesh/scoby/pkg/utils/resolver"
+
+	. "github.com/triggermesh/scoby/test"
+)
+
+const (
+	tScobyNamespace = "triggermesh"
+
+	tCRD = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: kuards.extensions.triggermesh.io
+spec:
+  group: extensions.triggermesh.io
+  scope: Namespaced
+  names:
+    plural: kuards
+    singular: kuard
+    kind: Kuard
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      subresources:
+        status: {}
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            spec:
+              type: object
+              properties:
+                variable1:
+                  type: string
+`
+
+	tInstance = `
+apiVersion: extensions.triggermesh.io/v1
+kind: Kuard
+metadata:
+  name: my-kuard
+  namespace: test
+  generation: 3
+spec:
+  variable1: value 1
+`
+)
+
+var tGVK = &schema.GroupVersionKind{
+	Group:   "extensions.triggermesh.io",
+	Version: "v1",
+	Kind:    "Kuard",
+}
+
+func TestRevisionName(t *testing.T) {
+	crdv := basecrd.CRDPrioritizedVersion(ReadCRD(tCRD))
+
+	newReconciler := func(revisionName string) *knserviceReconciler {
+		return &knserviceReconciler{
+			name: "kuard",
+			formFactor: &commonv1alpha1.KnativeServiceFormFactor{
+				RevisionName: revisionName,
+			},
+			fromImage: &commonv1alpha1.RegistrationFromImage{Repo: "registry.example.com/kuard:v1"},
+		}
+	}
+
+	// render returns the revision name for the Knative Service candidate,
+	// which can be modified as a hook would before it is reconciled.
+	render := func(t *testing.T, sr *knserviceReconciler, variable1 string, hook func(*servingv1.Service)) (string, error) {
+		client := fake.NewClientBuilder().Build()
+		r, err := baserenderer.NewRenderer(&commonv1alpha1.Workload{}, resolver.New(client),
+			configmap.NewNamespacedReader(tScobyNamespace, client))
+		require.NoError(t, err)
+
+		smf := basestatus.NewStatusManagerFactory(crdv, "", nil, tlogr.NewTestLogger(t))
+		obj := baseobject.NewManager(tGVK, r, smf).NewObject()
+		u := obj.AsKubeObject().(*unstructured.Unstructured)
+		require.NoError(t, yaml.Unmarshal([]byte(tInstance), u))
+		require.NoError(t, unstructured.SetNestedField(u.Object, variable1, "spec", "variable1"))
+		require.NoError(t, r.Render(context.Background(), obj))
+
+		ksvc, err := sr.createKnServiceFromRegistered(obj)
+		require.NoError(t, err)
+
+		// Candidates are exchanged with hooks as unstructured objects.
+		uksvc, err := runtime.DefaultUnstructuredConverter.ToUnstructured(ksvc)
+		require.NoError(t, err)
+		candidate := &servingv1.Service{}
+		require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(uksvc, candidate))
+
+		if hook != nil {
+			hook(candidate)
+		}
+
+		if err := sr.setRevisionName(obj, candidate); err != nil {
+			return "", err
+		}
+		return candidate.Spec.Template.Name, nil
+	}
+
+	t.Run("hash changes with env values", func(t *testing.T) {
+		sr := newReconciler("{{.ServiceName}}-{{.Generation}}-{{.Hash}}")
+
+		name1, err := render(t, sr, "value 1", nil)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(name1, "kuard-my-kuard-3-"), "unexpected revision name %q", name1)
+		assert.Len(t, strings.TrimPrefix(name1, "kuard-my-kuard-3-"), revisionHashLength)
+
+		again, err := render(t, sr, "value 1", nil)
+		require.NoError(t, err)
+		assert.Equal(t, name1, again, "revision name must be stable for the same template")
+
+		name2, err := render(t, sr, "value 2", nil)
+		require.NoError(t, err)
+		assert.NotEqual(t, name1, name2, "revision name must change when only an env value does")
+	})
+
+	t.Run("hash changes with hook modifications", func(t *testing.T) {
+		sr := newReconciler("{{.ServiceName}}-{{.Generation}}-{{.Hash}}")
+
+		name1, err := render(t, sr, "value 1", nil)
+		require.NoError(t, err)
+
+		name2, err := render(t, sr, "value 1", func(ksvc *servingv1.Service) {
+			ksvc.Spec.Template.Spec.Containers[0].Image = "registry.example.com/kuard:v2"
+		})
+		require.NoError(t, err)
+		assert.NotEqual(t, name1, name2, "revision name must change when a hook modifies the template")
+	})
+
+	t.Run("hash is required", func(t *testing.T) {
+		_, err := render(t, newReconciler("{{.ServiceName}}-{{.Generation}}"), "value 1", nil)
+		assert.ErrorContains(t, err, "must include the revision template hash")
+	})
+
+	t.Run("service name prefix is required", func(t *testing.T) {
+		_, err := render(t, newReconciler("rev-{{.Hash}}"), "value 1", nil)
+		assert.ErrorContains(t, err, "must be prefixed by the service name")
+	})
+}
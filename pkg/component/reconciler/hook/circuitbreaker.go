@@ -0,0 +1,122 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package hook
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/rickb777/date/period"
+
+	commonv1alpha1 "github.com/triggermesh/scoby/pkg/apis/common/v1alpha1"
+)
+
+const defaultResetTimeout = time.Second * 30
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker tracks consecutive failed calls to a hook. When the
+// failure threshold is reached the circuit opens and calls are rejected
+// until the reset timeout expires, after which a single trial call is
+// allowed. A successful trial closes the circuit, a failed one opens it
+// again.
+type circuitBreaker struct {
+	threshold    int
+	resetTimeout time.Duration
+
+	state    circuitState
+	failures int
+	openedAt time.Time
+
+	now func() time.Time
+	m   sync.Mutex
+}
+
+func newCircuitBreaker(cb *commonv1alpha1.HookCircuitBreaker, log logr.Logger) *circuitBreaker {
+	if cb == nil || cb.FailureThreshold <= 0 {
+		return nil
+	}
+
+	c := &circuitBreaker{
+		threshold:    int(cb.FailureThreshold),
+		resetTimeout: defaultResetTimeout,
+		now:          time.Now,
+	}
+
+	if cb.ResetTimeout != nil {
+		p, err := period.Parse(*cb.ResetTimeout)
+		if err != nil {
+			log.Error(err, "hook circuit breaker reset timeout is not an ISO 8601 duration", "resetTimeout", *cb.ResetTimeout)
+		} else {
+			c.resetTimeout = p.DurationApprox()
+		}
+	}
+
+	return c
+}
+
+// allow returns true when a call can be executed. When the circuit is open
+// it also returns the remaining time until a call will be allowed.
+func (c *circuitBreaker) allow() (bool, time.Duration) {
+	if c == nil {
+		return true, 0
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	switch c.state {
+	case circuitOpen:
+		elapsed := c.now().Sub(c.openedAt)
+		if elapsed < c.resetTimeout {
+			return false, c.resetTimeout - elapsed
+		}
+
+		// Let one trial call through.
+		c.state = circuitHalfOpen
+		return true, 0
+
+	case circuitHalfOpen:
+		// A trial call is in progress, reject until it finishes.
+		return false, c.resetTimeout
+	}
+
+	return true, 0
+}
+
+// success informs a successful call.
+func (c *circuitBreaker) success() {
+	if c == nil {
+		return
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.state = circuitClosed
+	c.failures = 0
+}
+
+// failure informs a failed call.
+func (c *circuitBreaker) failure() {
+	if c == nil {
+		return
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.failures++
+	if c.state == circuitHalfOpen || c.failures >= c.threshold {
+		c.state = circuitOpen
+		c.openedAt = c.now()
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package hook
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	hookv1 "github.com/triggermesh/scoby/pkg/apis/hook/v1"
+	"github.com/triggermesh/scoby/pkg/apis/hook/v1/hookpb"
+)
+
+// grpcTransport calls the hook gRPC service using a persistent connection.
+type grpcTransport struct {
+	target  string
+	timeout time.Duration
+
+	conn   *grpc.ClientConn
+	client hookpb.HookClient
+	err    error
+
+	log logr.Logger
+}
+
+func newGRPCTransport(address string, timeout time.Duration, log logr.Logger) *grpcTransport {
+	gt := &grpcTransport{
+		target:  address,
+		timeout: timeout,
+		log:     log,
+	}
+
+	// The resolved hook address is an URL, use its host as the target
+	// and the scheme to choose the transport security.
+	creds := insecure.NewCredentials()
+	if u, err := url.Parse(address); err == nil && u.Host != "" {
+		gt.target = u.Host
+		if u.Scheme == "https" {
+			creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+		}
+	}
+
+	// Dialing does not block, connection is established in the background
+	// and kept for subsequent calls.
+	gt.conn, gt.err = grpc.Dial(gt.target, grpc.WithTransportCredentials(creds))
+	if gt.err != nil {
+		log.Error(gt.err, "could not create gRPC connection to hook", "target", gt.target)
+		return gt
+	}
+
+	gt.client = hookpb.NewHookClient(gt.conn)
+
+	return gt
+}
+
+func (gt *grpcTransport) prepare(hreq *hookv1.HookRequest) (sendFunc, error) {
+	if gt.err != nil {
+		return nil, fmt.Errorf("gRPC connection to hook at %s is not available: %w", gt.target, gt.err)
+	}
+
+	req, err := hookpb.NewHookRequest(hreq)
+	if err != nil {
+		return nil, fmt.Errorf("could not convert hook request to protobuf: %w", err)
+	}
+
+	return func(ctx context.Context) (*hookv1.HookResponse, *hookv1.HookResponseError, bool, time.Duration) {
+		return gt.do(ctx, req)
+	}, nil
+}
+
+func (gt *grpcTransport) close() error {
+	if gt.conn == nil {
+		return nil
+	}
+	return gt.conn.Close()
+}
+
+func (gt *grpcTransport) do(ctx context.Context, req *hookpb.HookRequest) (*hookv1.HookResponse, *hookv1.HookResponseError, bool, time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, gt.timeout)
+	defer cancel()
+
+	res, err := gt.client.Reconcile(ctx, req)
+	if err != nil {
+		st := status.Convert(err)
+		return nil, &hookv1.HookResponseError{
+			// Do not mark as permanent to retry the hook
+			Permanent: ptrFalse,
+			Continue:  ptrFalse,
+			Err:       &transportError{fmt.Errorf("hook gRPC request to %s returned %s: %s", gt.target, st.Code(), st.Message())},
+		}, isRetriableCode(st.Code()), 0
+	}
+
+	// Structured errors are informed by the hook, which
+	// decides if the error is permanent or not.
+	hres, herr := hookpb.ToHookResponse(res)
+	if herr != nil {
+		return nil, herr, false, 0
+	}
+
+	gt.log.V(5).Info("Response received from hook", "response", res)
+
+	return hres, nil, false, 0
+}
+
+// isRetriableCode returns true for gRPC status codes that
+// might succeed when the request is retried.
+func isRetriableCode(code codes.Code) bool {
+	switch code {
+	case codes.Unavailable,
+		codes.ResourceExhausted,
+		codes.Aborted,
+		codes.DeadlineExceeded,
+		codes.Internal,
+		codes.Unknown:
+		return true
+	}
+
+	return false
+}
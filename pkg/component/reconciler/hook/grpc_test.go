@@ -0,0 +1,168 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package hook
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	commonv1alpha1 "github.com/triggermesh/scoby/pkg/apis/common/v1alpha1"
+	hookv1 "github.com/triggermesh/scoby/pkg/apis/hook/v1"
+	"github.com/triggermesh/scoby/pkg/apis/hook/v1/hookpb"
+	"github.com/triggermesh/scoby/pkg/component/reconciler"
+)
+
+type testHookServer struct {
+	hookpb.UnimplementedHookServer
+
+	calls     int32
+	failures  int32
+	code      codes.Code
+	hookError *hookpb.HookResponseError
+}
+
+func (s *testHookServer) Reconcile(ctx context.Context, req *hookpb.HookRequest) (*hookpb.HookResponse, error) {
+	if atomic.AddInt32(&s.calls, 1) <= s.failures {
+		return nil, status.Error(s.code, "test failure")
+	}
+
+	if s.hookError != nil {
+		return &hookpb.HookResponse{Error: s.hookError}, nil
+	}
+
+	// Echo the request adding a status field.
+	obj := req.GetObject()
+	obj.Fields["status"] = structpb.NewStructValue(&structpb.Struct{
+		Fields: map[string]*structpb.Value{"observedGeneration": structpb.NewNumberValue(3)},
+	})
+
+	return &hookpb.HookResponse{
+		Object:   obj,
+		Children: req.GetChildren(),
+	}, nil
+}
+
+func newTestGRPCServer(t *testing.T, hs hookpb.HookServer) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := grpc.NewServer()
+	hookpb.RegisterHookServer(srv, hs)
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	return "http://" + lis.Addr().String()
+}
+
+func TestGRPCHookCall(t *testing.T) {
+	grpcTransport := commonv1alpha1.HookTransportGRPC
+	perm := true
+
+	testCases := map[string]struct {
+		server      *testHookServer
+		expectCalls int32
+		expectErr   bool
+		expectPerm  bool
+	}{
+		"success": {
+			server:      &testHookServer{},
+			expectCalls: 1,
+		},
+		"transient error recovers": {
+			server:      &testHookServer{failures: 2, code: codes.Unavailable},
+			expectCalls: 3,
+		},
+		"not retriable code": {
+			server:      &testHookServer{failures: 5, code: codes.InvalidArgument},
+			expectCalls: 1,
+			expectErr:   true,
+		},
+		"structured errors are not retried": {
+			server:      &testHookServer{hookError: &hookpb.HookResponseError{Message: "hook says no", Permanent: &perm}},
+			expectCalls: 1,
+			expectErr:   true,
+			expectPerm:  true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			url := newTestGRPCServer(t, tc.server)
+
+			attempts := int32(3)
+			hr := newTestHookReconciler(t, url, &commonv1alpha1.Hook{
+				Transport: &grpcTransport,
+				Retry: &commonv1alpha1.HookRetry{
+					Attempts:     &attempts,
+					BackoffDelay: &tBackoff,
+					Jitter:       &tJitter,
+				},
+			})
+			defer hr.Close()
+
+			obj := unstructured.Unstructured{}
+			obj.SetName("test")
+
+			hres, herr := hr.call(context.Background(), &hookv1.HookRequest{
+				Phase:  hookv1.PhasePreReconcile,
+				Object: obj,
+				Children: map[string]*unstructured.Unstructured{
+					"omitted": nil,
+				},
+			})
+
+			assert.Equal(t, tc.expectCalls, atomic.LoadInt32(&tc.server.calls))
+			if tc.expectErr {
+				require.NotNil(t, herr)
+				assert.Equal(t, tc.expectPerm, herr.IsPermanent())
+				return
+			}
+
+			require.Nil(t, herr)
+			require.NotNil(t, hres.Object)
+			assert.Equal(t, "test", hres.Object.GetName())
+
+			og, _, _ := unstructured.NestedInt64(hres.Object.Object, "status", "observedGeneration")
+			assert.Equal(t, int64(3), og)
+
+			c, ok := hres.Children["omitted"]
+			assert.True(t, ok)
+			assert.Nil(t, c)
+		})
+	}
+}
+
+func TestGRPCHookCircuitBreaker(t *testing.T) {
+	grpcTransport := commonv1alpha1.HookTransportGRPC
+
+	// Hooks that do not implement the service fail with a code that is
+	// not retried, but still count as failed calls.
+	url := newTestGRPCServer(t, &hookpb.UnimplementedHookServer{})
+	hr := newTestHookReconciler(t, url, &commonv1alpha1.Hook{
+		Transport:      &grpcTransport,
+		CircuitBreaker: &commonv1alpha1.HookCircuitBreaker{FailureThreshold: 1},
+	})
+	defer hr.Close()
+
+	_, herr := hr.call(context.Background(), &hookv1.HookRequest{})
+	require.NotNil(t, herr)
+	assert.Contains(t, herr.Error(), codes.Unimplemented.String())
+
+	_, herr = hr.call(context.Background(), &hookv1.HookRequest{})
+	assert.True(t, errors.As(herr, new(*reconciler.HookCircuitOpenError)), "circuit should be open")
+}
@@ -0,0 +1,162 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package hook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	hookv1 "github.com/triggermesh/scoby/pkg/apis/hook/v1"
+)
+
+const defaultMaxIdleConnsPerHost = 16
+
+// sendFunc executes a single hook request. Besides the response and error it
+// returns whether the error is transient and the delay requested by the hook
+// before retrying.
+type sendFunc func(ctx context.Context) (*hookv1.HookResponse, *hookv1.HookResponseError, bool, time.Duration)
+
+// transportError wraps failures that prevent the hook from producing a
+// response, which are informed to the circuit breaker as failed calls.
+type transportError struct {
+	err error
+}
+
+func (e *transportError) Error() string {
+	return e.err.Error()
+}
+
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+// transport communicates with hooks.
+type transport interface {
+	// prepare encodes the request and returns the function that sends it,
+	// which can be called multiple times when retrying.
+	prepare(hreq *hookv1.HookRequest) (sendFunc, error)
+	close() error
+}
+
+// httpTransport sends JSON requests over HTTP.
+type httpTransport struct {
+	url    string
+	client *http.Client
+	log    logr.Logger
+}
+
+func newHTTPTransport(url string, timeout time.Duration, log logr.Logger) *httpTransport {
+	// Connections to the hook are pooled and reused across calls.
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+
+	return &httpTransport{
+		url: url,
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: t,
+		},
+		log: log,
+	}
+}
+
+func (ht *httpTransport) prepare(hreq *hookv1.HookRequest) (sendFunc, error) {
+	b, err := json.Marshal(hreq)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal hook request: %w", err)
+	}
+
+	return func(ctx context.Context) (*hookv1.HookResponse, *hookv1.HookResponseError, bool, time.Duration) {
+		return ht.do(ctx, b)
+	}, nil
+}
+
+func (ht *httpTransport) close() error {
+	ht.client.CloseIdleConnections()
+	return nil
+}
+
+func (ht *httpTransport) do(ctx context.Context, body []byte) (*hookv1.HookResponse, *hookv1.HookResponseError, bool, time.Duration) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ht.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, &hookv1.HookResponseError{
+			Permanent: ptrTrue,
+			Continue:  ptrFalse,
+			Err:       &transportError{fmt.Errorf("could not create hook request: %w", err)},
+		}, false, 0
+	}
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+
+	res, err := ht.client.Do(req)
+	if err != nil {
+		return nil, &hookv1.HookResponseError{
+			// Transport errors might be transient, let the
+			// request be retried.
+			Permanent: ptrFalse,
+			Continue:  ptrFalse,
+			Err:       &transportError{fmt.Errorf("executing hook request to %s: %w", ht.url, err)},
+		}, true, 0
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		retryAfter, _ := parseRetryAfter(res.Header.Get("Retry-After"), time.Now())
+
+		// Try to read any error message
+		b, err := io.ReadAll(res.Body)
+		if err != nil {
+			return nil, &hookv1.HookResponseError{
+				Permanent: ptrFalse,
+				Continue:  ptrFalse,
+				Err:       &transportError{fmt.Errorf("hook response from %s returning %d could not be read: %w", ht.url, res.StatusCode, err)},
+			}, true, retryAfter
+		}
+
+		// Try to convert to an structured error.
+		he := &hookv1.HookResponseError{}
+		err = json.Unmarshal(b, he)
+
+		// If the response does not contain an structured error treat it as a string.
+		if err != nil {
+			return nil, &hookv1.HookResponseError{
+				// Do not mark as permanent to retry the hook
+				Permanent: ptrFalse,
+				Continue:  ptrFalse,
+				Err:       &transportError{fmt.Errorf("hook request at %s returned %d: %s", ht.url, res.StatusCode, string(b))},
+			}, isRetriableStatusCode(res.StatusCode) || res.StatusCode >= 500, retryAfter
+		}
+
+		// Structured errors are informed by the hook, which
+		// decides if the error is permanent or not.
+		return nil, he, false, 0
+	}
+
+	hres := &hookv1.HookResponse{}
+	err = json.NewDecoder(res.Body).Decode(hres)
+	switch {
+	case err == io.EOF:
+		// an empty response that does not mean error, but
+		// noop from the hook, just return
+		return nil, nil, false, 0
+
+	case err != nil:
+		return nil, &hookv1.HookResponseError{
+			Permanent: ptrTrue,
+			Continue:  ptrFalse,
+			Err:       &transportError{fmt.Errorf("hook response from %s could not be parsed: %w", ht.url, err)},
+		}, false, 0
+	}
+
+	ht.log.V(5).Info("Response received from hook", "response", *res)
+
+	return hres, nil, false, 0
+}
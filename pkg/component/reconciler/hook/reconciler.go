@@ -4,25 +4,26 @@
 package hook
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"net/http"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/rickb777/date/period"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
 
 	commonv1alpha1 "github.com/triggermesh/scoby/pkg/apis/common/v1alpha1"
 	hookv1 "github.com/triggermesh/scoby/pkg/apis/hook/v1"
 	"github.com/triggermesh/scoby/pkg/component/reconciler"
 )
 
-const defaultTimeout = time.Second * 15
+const (
+	defaultTimeout = time.Second * 15
+)
 
 var (
 	_true    = true
@@ -32,20 +33,23 @@ var (
 )
 
 type hookReconciler struct {
-	url        string
 	timeout    time.Duration
 	conditions []commonv1alpha1.ConditionsFromHook
 
 	isPreReconciler bool
 	isFinalizer     bool
 
-	log logr.Logger
-	ffi *hookv1.FormFactorInfo
+	transport transport
+	retry     *retryPolicy
+	breaker   *circuitBreaker
+
+	recorder record.EventRecorder
+	log      logr.Logger
+	ffi      *hookv1.FormFactorInfo
 }
 
-func New(h *commonv1alpha1.Hook, url string, conditions []commonv1alpha1.ConditionsFromHook, ffi *hookv1.FormFactorInfo, log logr.Logger) reconciler.HookReconciler {
+func New(h *commonv1alpha1.Hook, url string, conditions []commonv1alpha1.ConditionsFromHook, ffi *hookv1.FormFactorInfo, recorder record.EventRecorder, log logr.Logger) reconciler.HookReconciler {
 	hr := &hookReconciler{
-		url:     url,
 		timeout: defaultTimeout,
 
 		isPreReconciler: h.Capabilities.IsPreReconciler(),
@@ -53,8 +57,9 @@ func New(h *commonv1alpha1.Hook, url string, conditions []commonv1alpha1.Conditi
 
 		conditions: conditions,
 
-		ffi: ffi,
-		log: log,
+		ffi:      ffi,
+		recorder: recorder,
+		log:      log,
 	}
 
 	if h.Timeout != nil {
@@ -66,16 +71,22 @@ func New(h *commonv1alpha1.Hook, url string, conditions []commonv1alpha1.Conditi
 		}
 	}
 
+	switch h.GetTransport() {
+	case commonv1alpha1.HookTransportGRPC:
+		hr.transport = newGRPCTransport(url, hr.timeout, log)
+	default:
+		hr.transport = newHTTPTransport(url, hr.timeout, log)
+	}
+
+	hr.retry = newRetryPolicy(h.Retry, log)
+	hr.breaker = newCircuitBreaker(h.CircuitBreaker, log)
+
 	return hr
 }
 
 func (hr *hookReconciler) PreReconcile(ctx context.Context, obj reconciler.Object, candidates *map[string]*unstructured.Unstructured) *hookv1.HookResponseError {
 	hr.log.V(1).Info("Pre-reconciling at hook", "obj", obj)
 
-	return hr.preReconcileHTTPRequest(ctx, obj, candidates)
-}
-
-func (hr *hookReconciler) preReconcileHTTPRequest(ctx context.Context, obj reconciler.Object, candidates *map[string]*unstructured.Unstructured) *hookv1.HookResponseError {
 	uobj, ok := obj.AsKubeObject().(*unstructured.Unstructured)
 	if !ok {
 		return &hookv1.HookResponseError{
@@ -85,91 +96,22 @@ func (hr *hookReconciler) preReconcileHTTPRequest(ctx context.Context, obj recon
 		}
 	}
 
-	b, err := json.Marshal(&hookv1.HookRequest{
+	hres, herr := hr.call(ctx, &hookv1.HookRequest{
 		FormFactor: *hr.ffi,
 		Object:     *uobj,
 		Phase:      hookv1.PhasePreReconcile,
 		Children:   *candidates,
 	})
-	if err != nil {
-		return &hookv1.HookResponseError{
-			Permanent: ptrTrue,
-			Continue:  ptrFalse,
-			Err:       fmt.Errorf("could not marshal hook request: %w", err),
-		}
-	}
-
-	req, err := http.NewRequest("POST", hr.url, bytes.NewBuffer(b))
-	if err != nil {
-		return &hookv1.HookResponseError{
-			Permanent: ptrTrue,
-			Continue:  ptrFalse,
-			Err:       fmt.Errorf("could create hook request: %w", err),
-		}
-	}
-	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
-
-	client := &http.Client{
-		Timeout: hr.timeout,
-	}
-
-	res, err := client.Do(req)
-	if err != nil {
-		return &hookv1.HookResponseError{
-			Permanent: ptrTrue,
-			Continue:  ptrFalse,
-			Err:       fmt.Errorf("executing hook request to %s: %w", hr.url, err),
-		}
-	}
-
-	defer res.Body.Close()
-
-	if res.StatusCode < 200 || res.StatusCode > 299 {
-		// Try to read any error message
-		b, err := io.ReadAll(res.Body)
-		if err != nil {
-			return &hookv1.HookResponseError{
-				Permanent: ptrFalse,
-				Continue:  ptrFalse,
-				Err:       fmt.Errorf("hook response from %s returning %d could not be read: %w", hr.url, res.StatusCode, err),
-			}
-		}
-
-		// Try to convert to an structured error.
-		he := &hookv1.HookResponseError{}
-		err = json.Unmarshal(b, he)
-
-		// If the response does not contain an structured error treat it as a string.
-		if err != nil {
-			return &hookv1.HookResponseError{
-				// Do not mark as permanent to retry the hook
-				Permanent: ptrFalse,
-				Continue:  ptrFalse,
-				Err:       fmt.Errorf("hook request at %s returned %d: %s", hr.url, res.StatusCode, string(b)),
-			}
-		}
-
-		return he
+	if herr != nil {
+		return herr
 	}
 
-	hres := &hookv1.HookResponse{}
-	err = json.NewDecoder(res.Body).Decode(hres)
-	switch {
-	case err == io.EOF:
-		// an empty response that does not mean error, but
-		// noop from the hook, just return
+	// an empty response that does not mean error, but
+	// noop from the hook, just return
+	if hres == nil {
 		return nil
-
-	case err != nil:
-		return &hookv1.HookResponseError{
-			Permanent: ptrTrue,
-			Continue:  ptrFalse,
-			Err:       fmt.Errorf("hook response from %s could not be parsed: %w", hr.url, err),
-		}
 	}
 
-	hr.log.V(5).Info("Response received from hook", "response", *res)
-
 	if hres.Children != nil && len(hres.Children) != 0 {
 		*candidates = hres.Children
 	}
@@ -191,14 +133,14 @@ func (hr *hookReconciler) IsFinalizer() bool {
 	return hr.isFinalizer
 }
 
+// Close releases the connections to the hook.
+func (hr *hookReconciler) Close() error {
+	return hr.transport.close()
+}
+
 func (hr *hookReconciler) Finalize(ctx context.Context, obj reconciler.Object) *hookv1.HookResponseError {
 	hr.log.V(1).Info("Finalizing at hook", "obj", obj)
 
-	err := hr.finalizerHTTPRequest(ctx, obj)
-	return err
-}
-
-func (hr *hookReconciler) finalizerHTTPRequest(ctx context.Context, obj reconciler.Object) *hookv1.HookResponseError {
 	uobj, ok := obj.AsKubeObject().(*unstructured.Unstructured)
 	if !ok {
 		return &hookv1.HookResponseError{
@@ -208,95 +150,94 @@ func (hr *hookReconciler) finalizerHTTPRequest(ctx context.Context, obj reconcil
 		}
 	}
 
-	b, err := json.Marshal(&hookv1.HookRequest{
+	hres, herr := hr.call(ctx, &hookv1.HookRequest{
 		FormFactor: *hr.ffi,
 		Object:     *uobj,
 		Phase:      hookv1.PhaseFinalize,
 	})
-	if err != nil {
-		return &hookv1.HookResponseError{
-			Permanent: ptrTrue,
-			Continue:  ptrFalse,
-			Err:       fmt.Errorf("could not marshal hook request: %w", err),
-		}
+	if herr != nil {
+		return herr
+	}
+
+	if hres == nil || hres.Object == nil {
+		return nil
 	}
 
-	req, err := http.NewRequest("POST", hr.url, bytes.NewBuffer(b))
+	*uobj = *hres.Object
+
+	return nil
+}
+
+// call sends the request to the hook, retrying transient failures according
+// to the retry policy and keeping track of failures at the circuit breaker.
+func (hr *hookReconciler) call(ctx context.Context, hreq *hookv1.HookRequest) (*hookv1.HookResponse, *hookv1.HookResponseError) {
+	send, err := hr.transport.prepare(hreq)
 	if err != nil {
-		return &hookv1.HookResponseError{
+		return nil, &hookv1.HookResponseError{
 			Permanent: ptrTrue,
 			Continue:  ptrFalse,
-			Err:       fmt.Errorf("could not create hook request: %w", err),
+			Err:       fmt.Errorf("could not prepare hook request: %w", err),
 		}
 	}
-	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
-
-	client := &http.Client{
-		Timeout: hr.timeout,
-	}
 
-	res, err := client.Do(req)
-	if err != nil {
-		return &hookv1.HookResponseError{
-			Permanent: ptrTrue,
+	if ok, after := hr.breaker.allow(); !ok {
+		return nil, &hookv1.HookResponseError{
+			Permanent: ptrFalse,
 			Continue:  ptrFalse,
-			Err:       fmt.Errorf("executing hook request to %s: %w", hr.url, err),
+			Err:       &reconciler.HookCircuitOpenError{RetryAfter: after},
 		}
 	}
 
-	defer res.Body.Close()
+	var hres *hookv1.HookResponse
+	var herr *hookv1.HookResponseError
+	var transient bool
 
-	if res.StatusCode < 200 || res.StatusCode > 299 {
-		// Try to read any error message
-		b, err := io.ReadAll(res.Body)
-		if err != nil {
-			return &hookv1.HookResponseError{
-				Permanent: ptrFalse,
-				Continue:  ptrFalse,
-				Err:       fmt.Errorf("hook response from %s returning %d could not be read: %w", hr.url, res.StatusCode, err),
+	for attempt := 0; ; attempt++ {
+		var retryAfter time.Duration
+		hres, herr, transient, retryAfter = send(ctx)
+		if herr == nil || !transient || attempt >= hr.retry.attempts {
+			break
+		}
+
+		delay := hr.retry.backoff(attempt)
+		if retryAfter > delay {
+			// Do not block the reconciliation cycle waiting for longer
+			// than the maximum backoff, let the request be requeued.
+			if retryAfter > hr.retry.maxBackoffDelay {
+				break
 			}
+			delay = retryAfter
 		}
 
-		// Try to convert to an structured error.
-		he := &hookv1.HookResponseError{}
-		err = json.Unmarshal(b, he)
+		hr.log.V(1).Info("Retrying hook request", "phase", hreq.Phase, "attempt", attempt+1, "delay", delay, "error", herr.Error())
+		if hr.recorder != nil {
+			hr.recorder.Eventf(&hreq.Object, corev1.EventTypeWarning, reconciler.EventReasonHookRetry,
+				"Retrying %s hook call in %s (attempt %d/%d): %s", hreq.Phase, delay, attempt+1, hr.retry.attempts, herr.Error())
+		}
 
-		// If the response does not contain an structured error treat it as a string.
-		if err != nil {
-			return &hookv1.HookResponseError{
-				// Do not mark as permanent to retry the hook
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			herr = &hookv1.HookResponseError{
 				Permanent: ptrFalse,
 				Continue:  ptrFalse,
-				Err:       fmt.Errorf("hook request at %s returned %d: %s", hr.url, res.StatusCode, string(b)),
+				Err:       &transportError{fmt.Errorf("hook request retries canceled: %w", ctx.Err())},
 			}
+		case <-t.C:
+			continue
 		}
-
-		return he
+		break
 	}
 
-	hres := &hookv1.HookResponse{}
-	err = json.NewDecoder(res.Body).Decode(hres)
-	switch {
-	case err == io.EOF:
-		// an empty response that does not mean error, but
-		// noop from the hook, just return
-		return nil
-
-	case err != nil:
-		return &hookv1.HookResponseError{
-			Permanent: ptrTrue,
-			Continue:  ptrFalse,
-			Err:       fmt.Errorf("hook response from %s could not be parsed: %w", hr.url, err),
-		}
+	// Transport failures count as failed calls whether they can be retried
+	// or not, responses produced by the hook count as successful calls.
+	var te *transportError
+	if herr != nil && errors.As(herr, &te) {
+		hr.breaker.failure()
+	} else {
+		hr.breaker.success()
 	}
 
-	hr.log.V(5).Info("Response received from hook", "response", *res)
-
-	if hres.Object == nil {
-		return nil
-	}
-
-	*uobj = *hres.Object
-
-	return nil
+	return hres, herr
 }
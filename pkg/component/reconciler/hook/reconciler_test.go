@@ -0,0 +1,263 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package hook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tlogr "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/client-go/tools/record"
+
+	commonv1alpha1 "github.com/triggermesh/scoby/pkg/apis/common/v1alpha1"
+	hookv1 "github.com/triggermesh/scoby/pkg/apis/hook/v1"
+	"github.com/triggermesh/scoby/pkg/component/reconciler"
+)
+
+var (
+	tBackoff = "PT0.01S"
+	tJitter  = false
+)
+
+func newTestHookReconciler(t *testing.T, url string, h *commonv1alpha1.Hook) *hookReconciler {
+	hr := New(h, url, nil, &hookv1.FormFactorInfo{Name: "deployment"}, record.NewFakeRecorder(10), tlogr.NewTestLogger(t))
+	return hr.(*hookReconciler)
+}
+
+func TestHookCallRetries(t *testing.T) {
+	testCases := map[string]struct {
+		attempts    int32
+		failures    int32
+		status      int
+		body        string
+		expectCalls int32
+		expectErr   bool
+		expectPerm  bool
+	}{
+		"no retries configured": {
+			attempts:    0,
+			failures:    1,
+			status:      http.StatusServiceUnavailable,
+			expectCalls: 1,
+			expectErr:   true,
+		},
+		"transient error recovers": {
+			attempts:    3,
+			failures:    2,
+			status:      http.StatusServiceUnavailable,
+			expectCalls: 3,
+		},
+		"retries exhausted": {
+			attempts:    2,
+			failures:    5,
+			status:      http.StatusBadGateway,
+			expectCalls: 3,
+			expectErr:   true,
+		},
+		"structured errors are not retried": {
+			attempts:    3,
+			failures:    5,
+			status:      http.StatusInternalServerError,
+			body:        `{"message":"hook says no","permanent":true}`,
+			expectCalls: 1,
+			expectErr:   true,
+			expectPerm:  true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var calls int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&calls, 1) <= tc.failures {
+					w.WriteHeader(tc.status)
+					_, _ = w.Write([]byte(tc.body))
+				}
+			}))
+			defer srv.Close()
+
+			hr := newTestHookReconciler(t, srv.URL, &commonv1alpha1.Hook{
+				Retry: &commonv1alpha1.HookRetry{
+					Attempts:     &tc.attempts,
+					BackoffDelay: &tBackoff,
+					Jitter:       &tJitter,
+				},
+			})
+
+			_, herr := hr.call(context.Background(), &hookv1.HookRequest{Phase: hookv1.PhasePreReconcile})
+
+			assert.Equal(t, tc.expectCalls, atomic.LoadInt32(&calls))
+			if !tc.expectErr {
+				assert.Nil(t, herr)
+				return
+			}
+
+			require.NotNil(t, herr)
+			assert.Equal(t, tc.expectPerm, herr.IsPermanent())
+		})
+	}
+}
+
+func TestHookCircuitBreaker(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	reset := "PT1M"
+	hr := newTestHookReconciler(t, srv.URL, &commonv1alpha1.Hook{
+		CircuitBreaker: &commonv1alpha1.HookCircuitBreaker{
+			FailureThreshold: 2,
+			ResetTimeout:     &reset,
+		},
+	})
+
+	now := time.Now()
+	hr.breaker.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		_, herr := hr.call(context.Background(), &hookv1.HookRequest{})
+		require.NotNil(t, herr)
+		assert.False(t, errors.As(herr, new(*reconciler.HookCircuitOpenError)), "circuit should not be open yet")
+	}
+
+	// Threshold reached, the hook must not be called.
+	_, herr := hr.call(context.Background(), &hookv1.HookRequest{})
+	require.NotNil(t, herr)
+
+	coe := &reconciler.HookCircuitOpenError{}
+	require.True(t, errors.As(herr, &coe), "circuit should be open")
+	assert.Equal(t, time.Minute, coe.RetryAfter)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.False(t, herr.IsPermanent())
+
+	// After the reset timeout a trial call is let through.
+	now = now.Add(time.Minute)
+	_, herr = hr.call(context.Background(), &hookv1.HookRequest{})
+	require.NotNil(t, herr)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	// The failed trial opens the circuit again.
+	_, herr = hr.call(context.Background(), &hookv1.HookRequest{})
+	require.True(t, errors.As(herr, &coe), "circuit should be open")
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestHookCircuitBreakerOutcomes(t *testing.T) {
+	testCases := map[string]struct {
+		status     int
+		body       string
+		expectOpen bool
+	}{
+		"not found": {
+			status:     http.StatusNotFound,
+			body:       "404 page not found",
+			expectOpen: true,
+		},
+		"not retriable status": {
+			status:     http.StatusBadRequest,
+			expectOpen: true,
+		},
+		"unparseable response": {
+			status:     http.StatusOK,
+			body:       "not a hook response",
+			expectOpen: true,
+		},
+		"structured error": {
+			status: http.StatusInternalServerError,
+			body:   `{"message":"hook says no","permanent":true}`,
+		},
+		"success": {
+			status: http.StatusOK,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer srv.Close()
+
+			hr := newTestHookReconciler(t, srv.URL, &commonv1alpha1.Hook{
+				CircuitBreaker: &commonv1alpha1.HookCircuitBreaker{FailureThreshold: 1},
+			})
+
+			_, _ = hr.call(context.Background(), &hookv1.HookRequest{})
+			_, herr := hr.call(context.Background(), &hookv1.HookRequest{})
+			assert.Equal(t, tc.expectOpen, errors.As(herr, new(*reconciler.HookCircuitOpenError)))
+		})
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	attempts := int32(5)
+	delay := "PT1S"
+	maxDelay := "PT5S"
+
+	rp := newRetryPolicy(&commonv1alpha1.HookRetry{
+		Attempts:        &attempts,
+		BackoffDelay:    &delay,
+		MaxBackoffDelay: &maxDelay,
+		Jitter:          &tJitter,
+	}, tlogr.NewTestLogger(t))
+
+	assert.Equal(t, time.Second, rp.backoff(0))
+	assert.Equal(t, 2*time.Second, rp.backoff(1))
+	assert.Equal(t, 4*time.Second, rp.backoff(2))
+	assert.Equal(t, 5*time.Second, rp.backoff(3))
+
+	rp.jitter = true
+	for i := 0; i < 10; i++ {
+		d := rp.backoff(1)
+		assert.GreaterOrEqual(t, d, time.Second)
+		assert.Less(t, d, 2*time.Second)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		in       string
+		expected time.Duration
+		ok       bool
+	}{
+		"empty": {
+			in: "",
+		},
+		"seconds": {
+			in:       "3",
+			expected: 3 * time.Second,
+			ok:       true,
+		},
+		"http date": {
+			in:       now.Add(time.Minute).Format(http.TimeFormat),
+			expected: time.Minute,
+			ok:       true,
+		},
+		"not valid": {
+			in: "tomorrow",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			d, ok := parseRetryAfter(tc.in, now)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, d)
+		})
+	}
+}
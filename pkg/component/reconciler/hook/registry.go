@@ -0,0 +1,100 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package hook
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	hookv1 "github.com/triggermesh/scoby/pkg/apis/hook/v1"
+	"github.com/triggermesh/scoby/pkg/component/reconciler"
+)
+
+// Registry keeps in-process hook reconcilers that controllers built on
+// top of Scoby can provide instead of deploying a hook service.
+//
+// Hooks can be registered for a registration name or for the GVK of the
+// registered CRD. When both match, the registration name takes precedence.
+// The same hook reconciler might be used concurrently for many objects.
+type Registry struct {
+	byName map[string]reconciler.HookReconciler
+	byGVK  map[schema.GroupVersionKind]reconciler.HookReconciler
+
+	m sync.RWMutex
+}
+
+// NewRegistry creates an empty in-process hook registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		byName: make(map[string]reconciler.HookReconciler),
+		byGVK:  make(map[schema.GroupVersionKind]reconciler.HookReconciler),
+	}
+}
+
+// RegisterForRegistration sets the hook reconciler for the registration name.
+func (r *Registry) RegisterForRegistration(name string, hr reconciler.HookReconciler) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	r.byName[name] = hr
+}
+
+// RegisterForGVK sets the hook reconciler for the registered CRD GVK.
+func (r *Registry) RegisterForGVK(gvk schema.GroupVersionKind, hr reconciler.HookReconciler) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	r.byGVK[gvk] = hr
+}
+
+// Lookup returns the hook reconciler for the registration name or GVK.
+func (r *Registry) Lookup(name string, gvk schema.GroupVersionKind) (reconciler.HookReconciler, bool) {
+	if r == nil {
+		return nil, false
+	}
+
+	r.m.RLock()
+	defer r.m.RUnlock()
+
+	if hr, ok := r.byName[name]; ok {
+		return hr, true
+	}
+
+	hr, ok := r.byGVK[gvk]
+	return hr, ok
+}
+
+// Funcs implements a hook reconciler using functions. The hook capabilities
+// are those whose function is informed.
+type Funcs struct {
+	PreReconcileFunc func(ctx context.Context, obj reconciler.Object, candidates *map[string]*unstructured.Unstructured) *hookv1.HookResponseError
+	FinalizeFunc     func(ctx context.Context, obj reconciler.Object) *hookv1.HookResponseError
+}
+
+var _ reconciler.HookReconciler = (*Funcs)(nil)
+
+func (f *Funcs) PreReconcile(ctx context.Context, obj reconciler.Object, candidates *map[string]*unstructured.Unstructured) *hookv1.HookResponseError {
+	if f.PreReconcileFunc == nil {
+		return nil
+	}
+	return f.PreReconcileFunc(ctx, obj, candidates)
+}
+
+func (f *Funcs) Finalize(ctx context.Context, obj reconciler.Object) *hookv1.HookResponseError {
+	if f.FinalizeFunc == nil {
+		return nil
+	}
+	return f.FinalizeFunc(ctx, obj)
+}
+
+func (f *Funcs) IsPreReconciler() bool {
+	return f.PreReconcileFunc != nil
+}
+
+func (f *Funcs) IsFinalizer() bool {
+	return f.FinalizeFunc != nil
+}
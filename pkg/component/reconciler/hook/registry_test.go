@@ -0,0 +1,78 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package hook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	hookv1 "github.com/triggermesh/scoby/pkg/apis/hook/v1"
+	"github.com/triggermesh/scoby/pkg/component/reconciler"
+)
+
+func TestRegistryLookup(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "extensions.triggermesh.io", Version: "v1", Kind: "Kuard"}
+
+	byName := &Funcs{}
+	byGVK := &Funcs{}
+
+	r := NewRegistry()
+	r.RegisterForRegistration("kuard", byName)
+	r.RegisterForGVK(gvk, byGVK)
+
+	testCases := map[string]struct {
+		name     string
+		gvk      schema.GroupVersionKind
+		expected reconciler.HookReconciler
+	}{
+		"registration name takes precedence": {
+			name:     "kuard",
+			gvk:      gvk,
+			expected: byName,
+		},
+		"match by GVK": {
+			name:     "other",
+			gvk:      gvk,
+			expected: byGVK,
+		},
+		"not found": {
+			name: "other",
+			gvk:  gvk.GroupVersion().WithKind("Other"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			hr, ok := r.Lookup(tc.name, tc.gvk)
+			if tc.expected == nil {
+				assert.False(t, ok)
+				return
+			}
+
+			assert.True(t, ok)
+			assert.Same(t, tc.expected, hr)
+		})
+	}
+
+	var nilRegistry *Registry
+	_, ok := nilRegistry.Lookup("kuard", gvk)
+	assert.False(t, ok, "nil registry should not contain hooks")
+}
+
+func TestFuncsCapabilities(t *testing.T) {
+	f := &Funcs{
+		FinalizeFunc: func(ctx context.Context, obj reconciler.Object) *hookv1.HookResponseError {
+			return &hookv1.HookResponseError{Message: "finalize"}
+		},
+	}
+
+	assert.False(t, f.IsPreReconciler())
+	assert.True(t, f.IsFinalizer())
+	assert.Nil(t, f.PreReconcile(context.Background(), nil, nil))
+	assert.Equal(t, "finalize", f.Finalize(context.Background(), nil).Error())
+}
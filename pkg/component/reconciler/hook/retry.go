@@ -0,0 +1,145 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package hook
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/rickb777/date/period"
+
+	commonv1alpha1 "github.com/triggermesh/scoby/pkg/apis/common/v1alpha1"
+)
+
+const (
+	defaultBackoffDelay    = time.Second
+	defaultMaxBackoffDelay = time.Second * 30
+)
+
+// retryPolicy is the parsed version of the registration's hook retry settings.
+type retryPolicy struct {
+	attempts        int
+	backoffDelay    time.Duration
+	maxBackoffDelay time.Duration
+	jitter          bool
+
+	rnd *rand.Rand
+	m   sync.Mutex
+}
+
+func newRetryPolicy(r *commonv1alpha1.HookRetry, log logr.Logger) *retryPolicy {
+	rp := &retryPolicy{
+		backoffDelay:    defaultBackoffDelay,
+		maxBackoffDelay: defaultMaxBackoffDelay,
+		jitter:          true,
+		rnd:             rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+
+	if r == nil {
+		return rp
+	}
+
+	if r.Attempts != nil && *r.Attempts > 0 {
+		rp.attempts = int(*r.Attempts)
+	}
+
+	if r.BackoffDelay != nil {
+		p, err := period.Parse(*r.BackoffDelay)
+		if err != nil {
+			log.Error(err, "hook retry backoff delay is not an ISO 8601 duration", "backoffDelay", *r.BackoffDelay)
+		} else {
+			rp.backoffDelay = p.DurationApprox()
+		}
+	}
+
+	if r.MaxBackoffDelay != nil {
+		p, err := period.Parse(*r.MaxBackoffDelay)
+		if err != nil {
+			log.Error(err, "hook retry max backoff delay is not an ISO 8601 duration", "maxBackoffDelay", *r.MaxBackoffDelay)
+		} else {
+			rp.maxBackoffDelay = p.DurationApprox()
+		}
+	}
+
+	if rp.maxBackoffDelay < rp.backoffDelay {
+		rp.maxBackoffDelay = rp.backoffDelay
+	}
+
+	if r.Jitter != nil {
+		rp.jitter = *r.Jitter
+	}
+
+	return rp
+}
+
+// backoff returns the delay before the retry number n, starting at 0.
+// Delay grows exponentially, is capped by the max backoff delay and
+// if jitter is enabled a random value in the [delay/2, delay) range is
+// returned.
+func (rp *retryPolicy) backoff(n int) time.Duration {
+	d := rp.backoffDelay
+	for i := 0; i < n && d < rp.maxBackoffDelay; i++ {
+		d *= 2
+	}
+
+	if d > rp.maxBackoffDelay {
+		d = rp.maxBackoffDelay
+	}
+
+	if !rp.jitter || d <= 1 {
+		return d
+	}
+
+	rp.m.Lock()
+	defer rp.m.Unlock()
+
+	half := d / 2
+	return half + time.Duration(rp.rnd.Int63n(int64(d-half)))
+}
+
+// isRetriableStatusCode returns true for HTTP status codes that
+// might succeed when the request is retried.
+func isRetriableStatusCode(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// parseRetryAfter parses the Retry-After header value, which can be informed
+// either as a number of seconds or as an HTTP date. Returns false if the
+// header is not present or cannot be parsed.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if s, err := strconv.Atoi(value); err == nil {
+		if s < 0 {
+			return 0, false
+		}
+		return time.Duration(s) * time.Second, true
+	}
+
+	t, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	d := t.Sub(now)
+	if d < 0 {
+		d = 0
+	}
+
+	return d, true
+}
@@ -10,6 +10,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -59,6 +60,43 @@ type ObjectRender interface {
 	// the volume mount.
 	AddVolumeMount(path string, vm *commonv1alpha1.FromSpecToVolume)
 
+	// SetEnvVarContainers is used by a renderer to set the containers
+	// that the environment variable rendered from the path is added to.
+	SetEnvVarContainers(path string, cs commonv1alpha1.ContainerSelector)
+
+	// SetContainerSettings is used by a renderer to set the workload
+	// container settings, after applying instance overrides.
+	SetContainerSettings(cs *commonv1alpha1.ContainerSettings)
+
+	// SetSchedulingSettings is used by a renderer to set the workload
+	// pods scheduling settings, after applying instance overrides.
+	SetSchedulingSettings(ss *commonv1alpha1.SchedulingSettings)
+
+	// SetSecuritySettings is used by a renderer to set the workload
+	// security contexts.
+	SetSecuritySettings(sc *commonv1alpha1.SecurityConfiguration)
+
+	// SetImage is used by a renderer to set the workload container image
+	// and pull policy, after applying instance overrides.
+	SetImage(image string, pullPolicy corev1.PullPolicy)
+
+	// AddImagePullSecret is used by a renderer to add a pull secret to
+	// the workload. When copyFrom is informed the secret is a copy of
+	// the one with that name at the Scoby namespace.
+	AddImagePullSecret(name, copyFrom string)
+
+	// SetAdditionalContainers is used by a renderer to set the init
+	// containers and sidecars for the workload pods.
+	SetAdditionalContainers(initContainers, sidecars []commonv1alpha1.AdditionalContainer)
+
+	// SetServiceAccountName sets the ServiceAccount the workload
+	// runs as.
+	SetServiceAccountName(name string)
+
+	// GetImagePullSecretCopies returns the pull secrets that need to be
+	// copied from the Scoby namespace, indexed by the copy name.
+	GetImagePullSecretCopies() map[string]string
+
 	// Once rendered an object can be queried about the container options
 	// that they resulting worload must include.
 	AsContainerOptions() []resources.ContainerOption
@@ -72,10 +110,17 @@ type StatusManager interface {
 	GetObservedGeneration() int64
 	SetObservedGeneration(int64)
 	GetCondition(conditionType string) *commonv1alpha1.Condition
+	// GetHappyCondition returns the condition that summarizes the object
+	// status, nil if conditions are not written at the object.
+	GetHappyCondition() *commonv1alpha1.Condition
 	SetCondition(condition *commonv1alpha1.Condition)
 	SanitizeConditions()
 	GetAddressURL() string
 	SetAddressURL(string)
+	// GetPublicURL and SetPublicURL manage the URL that exposes the
+	// workload outside the cluster.
+	GetPublicURL() string
+	SetPublicURL(string)
 	SetValue(value interface{}, path ...string) error
 	SetAnnotation(key, value string) error
 }
@@ -107,6 +152,26 @@ type FormFactorReconciler interface {
 	Reconcile(context.Context, Object, map[string]*unstructured.Unstructured) (ctrl.Result, error)
 }
 
+// FormFactorOption sets optional parameters for form factor reconcilers.
+type FormFactorOption func(*FormFactorOptions)
+
+// FormFactorOptions contains the optional parameters for form factor
+// reconcilers, which default to the manager's cache and client.
+type FormFactorOptions struct {
+	Cache  cache.Cache
+	Client client.Client
+}
+
+// WithCache sets the cache used to watch the form factor objects and the
+// client used to manage them, when the controller is restricted to a set
+// of namespaces.
+func WithCache(c cache.Cache, cl client.Client) FormFactorOption {
+	return func(o *FormFactorOptions) {
+		o.Cache = c
+		o.Client = cl
+	}
+}
+
 type HookReconciler interface {
 	PreReconcile(ctx context.Context, object Object, candidates *map[string]*unstructured.Unstructured) *hookv1.HookResponseError
 	Finalize(ctx context.Context, object Object) *hookv1.HookResponseError
@@ -0,0 +1,202 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package crd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	scobyv1alpha1 "github.com/triggermesh/scoby/pkg/apis/scoby/v1alpha1"
+	"github.com/triggermesh/scoby/pkg/component/reconciler"
+	"github.com/triggermesh/scoby/pkg/utils/resources"
+)
+
+// reconcileGeneratedCRD makes sure that the CRD generated from the
+// registration exists and is up to date.
+func (r *Reconciler) reconcileGeneratedCRD(ctx context.Context, cr *scobyv1alpha1.CRDRegistration) error {
+	desired, err := generateCRD(cr)
+	if err != nil {
+		return err
+	}
+
+	if err := controllerutil.SetControllerReference(cr, desired, r.client.Scheme()); err != nil {
+		return fmt.Errorf("could not set owner for generated CRD: %w", err)
+	}
+
+	existing := &apiextensionsv1.CustomResourceDefinition{}
+	err = r.client.Get(ctx, types.NamespacedName{Name: desired.Name}, existing)
+	switch {
+	case apierrs.IsNotFound(err):
+		r.log.Info("Creating generated CRD", "crd", desired.Name)
+		if err := r.client.Create(ctx, desired); err != nil {
+			return fmt.Errorf("could not create generated CRD: %w", err)
+		}
+		return nil
+
+	case err != nil:
+		return fmt.Errorf("could not retrieve generated CRD: %w", err)
+	}
+
+	if !metav1.IsControlledBy(existing, cr) {
+		return fmt.Errorf("CRD %s already exists and is not owned by this registration", existing.Name)
+	}
+
+	// Only elements set by the generator are compared, the rest
+	// of the spec is defaulted by the API server.
+	if equality.Semantic.DeepEqual(existing.Spec.Names, desired.Spec.Names) &&
+		equality.Semantic.DeepEqual(existing.Spec.Versions, desired.Spec.Versions) {
+		return nil
+	}
+
+	r.log.Info("Updating generated CRD", "crd", desired.Name)
+	existing.Spec.Names = desired.Spec.Names
+	existing.Spec.Versions = desired.Spec.Versions
+	if err := r.client.Update(ctx, existing); err != nil {
+		return fmt.Errorf("could not update generated CRD: %w", err)
+	}
+
+	return nil
+}
+
+// generateCRD creates the CRD from the registration compact schema. The
+// CRD includes a status that Scoby fills with conditions, observed
+// generation, annotations, address and public URL.
+func generateCRD(cr *scobyv1alpha1.CRDRegistration) (*apiextensionsv1.CustomResourceDefinition, error) {
+	g := cr.Spec.Generate
+	if g.Group == "" || g.Names.Kind == "" {
+		return nil, fmt.Errorf("generated CRD requires group and kind")
+	}
+
+	spec := &apiextensionsv1.JSONSchemaProps{}
+	if g.Spec != nil && len(g.Spec.Raw) != 0 {
+		if err := json.Unmarshal(g.Spec.Raw, spec); err != nil {
+			return nil, fmt.Errorf("could not parse generated CRD spec schema: %w", err)
+		}
+	}
+
+	switch spec.Type {
+	case "":
+		spec.Type = "object"
+	case "object":
+	default:
+		return nil, fmt.Errorf("generated CRD spec schema must be an object, found %q", spec.Type)
+	}
+
+	if len(spec.Properties) == 0 {
+		preserve := true
+		spec.XPreserveUnknownFields = &preserve
+	}
+
+	// The first column informs the condition that summarizes the rest.
+	happy := reconciler.ConditionTypeReady
+	if sc := cr.Spec.Workload.StatusConfiguration; sc != nil && sc.HappyCondition != "" {
+		happy = sc.HappyCondition
+	}
+
+	crdv, err := resources.NewCRDVersion(g.GetVersion(), true, true, spec,
+		resources.CRDVersionWithStatusSchema(statusSchema()),
+		resources.CRDVersionWithPrinterColumns(
+			apiextensionsv1.CustomResourceColumnDefinition{
+				Name:     happy,
+				Type:     "string",
+				JSONPath: ".status.conditions[?(@.type=='" + happy + "')].status",
+			},
+			apiextensionsv1.CustomResourceColumnDefinition{
+				Name:     "URL",
+				Type:     "string",
+				JSONPath: ".status.address.url",
+			},
+			apiextensionsv1.CustomResourceColumnDefinition{
+				Name:     "Age",
+				Type:     "date",
+				JSONPath: ".metadata.creationTimestamp",
+			},
+		))
+	if err != nil {
+		return nil, fmt.Errorf("could not generate CRD version: %w", err)
+	}
+
+	categories := g.Names.Categories
+	if len(categories) == 0 {
+		categories = []string{"all"}
+	}
+
+	return resources.NewCRD(cr.GetCRDName(),
+		resources.CRDWithNames(&apiextensionsv1.CustomResourceDefinitionNames{
+			Kind:       g.Names.Kind,
+			ListKind:   g.Names.Kind + "List",
+			Plural:     g.GetPlural(),
+			Singular:   g.GetSingular(),
+			ShortNames: g.Names.ShortNames,
+			Categories: categories,
+		}),
+		resources.CRDAddVersion(crdv),
+		resources.CRDWithMetaOptions(
+			resources.MetaAddLabel(resources.AppManagedByLabel, reconciler.ManagedBy),
+		))
+}
+
+// statusSchema returns the status schema that enables all status
+// capabilities.
+func statusSchema() *apiextensionsv1.JSONSchemaProps {
+	return &apiextensionsv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensionsv1.JSONSchemaProps{
+			"observedGeneration": {
+				Type:   "integer",
+				Format: "int64",
+			},
+			"annotations": {
+				Type: "object",
+				AdditionalProperties: &apiextensionsv1.JSONSchemaPropsOrBool{
+					Allows: true,
+					Schema: &apiextensionsv1.JSONSchemaProps{Type: "string"},
+				},
+			},
+			"address": {
+				Type: "object",
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{
+					"url": {Type: "string"},
+				},
+			},
+			"url": {Type: "string"},
+			"conditions": {
+				Type: "array",
+				Items: &apiextensionsv1.JSONSchemaPropsOrArray{
+					Schema: &apiextensionsv1.JSONSchemaProps{
+						Type:     "object",
+						Required: []string{"type", "status"},
+						Properties: map[string]apiextensionsv1.JSONSchemaProps{
+							"type":    {Type: "string"},
+							"status":  {Type: "string", Enum: enumStrings("True", "False", "Unknown")},
+							"reason":  {Type: "string"},
+							"message": {Type: "string"},
+							"lastTransitionTime": {
+								Type:   "string",
+								Format: "date-time",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func enumStrings(values ...string) []apiextensionsv1.JSON {
+	enum := make([]apiextensionsv1.JSON, 0, len(values))
+	for _, v := range values {
+		enum = append(enum, apiextensionsv1.JSON{Raw: []byte(`"` + v + `"`)})
+	}
+	return enum
+}
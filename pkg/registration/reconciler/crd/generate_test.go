@@ -0,0 +1,132 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package crd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	commonv1alpha1 "github.com/triggermesh/scoby/pkg/apis/common/v1alpha1"
+	scobyv1alpha1 "github.com/triggermesh/scoby/pkg/apis/scoby/v1alpha1"
+	basecrd "github.com/triggermesh/scoby/pkg/component/reconciler/base/crd"
+)
+
+func TestGenerateCRD(t *testing.T) {
+	testCases := map[string]struct {
+		generate       *scobyv1alpha1.GeneratedCRD
+		happyCondition string
+
+		expectedName    string
+		expectedVersion string
+		expectedSpec    []string
+		expectedErr     string
+	}{
+		"defaults": {
+			generate: &scobyv1alpha1.GeneratedCRD{
+				Group: "extensions.triggermesh.io",
+				Names: scobyv1alpha1.GeneratedCRDNames{Kind: "Kuard"},
+			},
+			expectedName:    "kuards.extensions.triggermesh.io",
+			expectedVersion: "v1alpha1",
+		},
+		"spec schema": {
+			generate: &scobyv1alpha1.GeneratedCRD{
+				Group:   "extensions.triggermesh.io",
+				Version: "v1",
+				Names:   scobyv1alpha1.GeneratedCRDNames{Kind: "Kuard", Plural: "kuardies"},
+				Spec: &runtime.RawExtension{Raw: []byte(
+					`{"properties":{"variable1":{"type":"string"},"variable2":{"type":"integer"}}}`)},
+			},
+			expectedName:    "kuardies.extensions.triggermesh.io",
+			expectedVersion: "v1",
+			expectedSpec:    []string{"variable1", "variable2"},
+		},
+		"happy condition": {
+			generate: &scobyv1alpha1.GeneratedCRD{
+				Group: "extensions.triggermesh.io",
+				Names: scobyv1alpha1.GeneratedCRDNames{Kind: "Kuard"},
+			},
+			happyCondition:  "Serving",
+			expectedName:    "kuards.extensions.triggermesh.io",
+			expectedVersion: "v1alpha1",
+		},
+		"spec schema not an object": {
+			generate: &scobyv1alpha1.GeneratedCRD{
+				Group: "extensions.triggermesh.io",
+				Names: scobyv1alpha1.GeneratedCRDNames{Kind: "Kuard"},
+				Spec:  &runtime.RawExtension{Raw: []byte(`{"type":"string"}`)},
+			},
+			expectedErr: `generated CRD spec schema must be an object, found "string"`,
+		},
+		"missing kind": {
+			generate: &scobyv1alpha1.GeneratedCRD{
+				Group: "extensions.triggermesh.io",
+			},
+			expectedErr: "generated CRD requires group and kind",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cr := &scobyv1alpha1.CRDRegistration{
+				ObjectMeta: metav1.ObjectMeta{Name: "kuard"},
+				Spec: scobyv1alpha1.CRDRegistrationSpec{
+					Generate: tc.generate,
+				},
+			}
+			if tc.happyCondition != "" {
+				cr.Spec.Workload.StatusConfiguration = &commonv1alpha1.StatusConfiguration{
+					HappyCondition: tc.happyCondition,
+				}
+			}
+
+			crd, err := generateCRD(cr)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedName, crd.Name)
+			assert.Equal(t, tc.expectedName, cr.GetCRDName())
+
+			crdv := basecrd.CRDPrioritizedVersion(crd)
+			require.NotNil(t, crdv)
+			assert.Equal(t, tc.expectedVersion, crdv.Name)
+
+			// The generated status supports every Scoby capability.
+			sf := basecrd.CRDStatusFlag(crdv)
+			assert.Equal(t, []string{"observedGeneration", "annotations", "conditions", "address", "url"}, sf.Capabilities())
+			for _, f := range []string{"reason", "message", "lastTransitionTime"} {
+				assert.True(t, sf.AllowConditionField(f), "condition field %s not allowed", f)
+			}
+
+			spec := crdv.Schema.OpenAPIV3Schema.Properties["spec"]
+			assert.Equal(t, "object", spec.Type)
+			if len(tc.expectedSpec) == 0 {
+				assert.True(t, *spec.XPreserveUnknownFields)
+			}
+			for _, p := range tc.expectedSpec {
+				assert.Contains(t, spec.Properties, p)
+			}
+
+			happy := "Ready"
+			if tc.happyCondition != "" {
+				happy = tc.happyCondition
+			}
+
+			columns := []string{}
+			for _, c := range crdv.AdditionalPrinterColumns {
+				columns = append(columns, c.Name)
+			}
+			assert.Equal(t, []string{happy, "URL", "Age"}, columns)
+			assert.Equal(t, ".status.conditions[?(@.type=='"+happy+"')].status", crdv.AdditionalPrinterColumns[0].JSONPath)
+		})
+	}
+}
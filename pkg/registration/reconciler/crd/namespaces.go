@@ -0,0 +1,121 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package crd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	scobyv1alpha1 "github.com/triggermesh/scoby/pkg/apis/scoby/v1alpha1"
+)
+
+// selectNamespaces returns the sorted list of namespaces that match the
+// registration namespace selector, restricted to the working namespaces
+// when configured. A nil slice is returned when the registration has
+// no selector, meaning that all namespaces are selected.
+func (r *Reconciler) selectNamespaces(ctx context.Context, cr *scobyv1alpha1.CRDRegistration) ([]string, error) {
+	if cr.Spec.NamespaceSelector == nil {
+		return nil, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(cr.Spec.NamespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid namespace selector: %w", err)
+	}
+
+	nsl := &corev1.NamespaceList{}
+	if err := r.client.List(ctx, nsl, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("could not list namespaces: %w", err)
+	}
+
+	working := make(map[string]struct{}, len(r.workingNamespaces))
+	for _, ns := range r.workingNamespaces {
+		working[ns] = struct{}{}
+	}
+
+	namespaces := []string{}
+	for i := range nsl.Items {
+		name := nsl.Items[i].Name
+		if _, ok := working[name]; len(working) != 0 && !ok {
+			continue
+		}
+		namespaces = append(namespaces, name)
+	}
+	sort.Strings(namespaces)
+
+	return namespaces, nil
+}
+
+// overlappingRegistration returns the name of a registration for the same
+// CRD whose namespaces overlap and that takes precedence, which is the
+// oldest registration or, when created at the same time, the first one
+// in alphabetical order. An empty string is returned when there is no
+// overlapping registration that takes precedence.
+func (r *Reconciler) overlappingRegistration(ctx context.Context, cr *scobyv1alpha1.CRDRegistration, namespaces []string) (string, error) {
+	crl := &scobyv1alpha1.CRDRegistrationList{}
+	if err := r.client.List(ctx, crl); err != nil {
+		return "", fmt.Errorf("could not list registrations: %w", err)
+	}
+
+	for i := range crl.Items {
+		other := &crl.Items[i]
+		if other.Name == cr.Name ||
+			!other.DeletionTimestamp.IsZero() ||
+			other.GetCRDName() != cr.GetCRDName() ||
+			!precedes(other, cr) {
+			continue
+		}
+
+		otherNamespaces, err := r.selectNamespaces(ctx, other)
+		if err != nil {
+			// Registrations with wrong selectors do not run controllers.
+			continue
+		}
+
+		if overlap(namespaces, otherNamespaces) {
+			return other.Name, nil
+		}
+	}
+
+	return "", nil
+}
+
+// precedes returns true when registration a takes precedence over b.
+func precedes(a, b *scobyv1alpha1.CRDRegistration) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return a.Name < b.Name
+}
+
+// overlap returns true when namespace sets intersect. A nil set
+// contains all namespaces.
+func overlap(a, b []string) bool {
+	if (a != nil && len(a) == 0) || (b != nil && len(b) == 0) {
+		return false
+	}
+
+	if a == nil || b == nil {
+		return true
+	}
+
+	set := make(map[string]struct{}, len(a))
+	for _, ns := range a {
+		set[ns] = struct{}{}
+	}
+
+	for _, ns := range b {
+		if _, ok := set[ns]; ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,163 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package crd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tlogr "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scobyv1alpha1 "github.com/triggermesh/scoby/pkg/apis/scoby/v1alpha1"
+)
+
+func newNamespace(name, tenant string) *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"tenant": tenant},
+		},
+	}
+}
+
+func newRegistration(name, crd string, created time.Time, tenant string) *scobyv1alpha1.CRDRegistration {
+	cr := &scobyv1alpha1.CRDRegistration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: scobyv1alpha1.CRDRegistrationSpec{
+			CRD: crd,
+		},
+	}
+
+	if tenant != "" {
+		cr.Spec.NamespaceSelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{"tenant": tenant},
+		}
+	}
+
+	return cr
+}
+
+func TestSelectNamespaces(t *testing.T) {
+	objects := []client.Object{
+		newNamespace("blue-1", "blue"),
+		newNamespace("blue-2", "blue"),
+		newNamespace("red-1", "red"),
+	}
+
+	testCases := map[string]struct {
+		tenant            string
+		workingNamespaces []string
+		expected          []string
+	}{
+		"no selector": {
+			expected: nil,
+		},
+		"selector": {
+			tenant:   "blue",
+			expected: []string{"blue-1", "blue-2"},
+		},
+		"selector restricted to working namespaces": {
+			tenant:            "blue",
+			workingNamespaces: []string{"blue-2", "red-1"},
+			expected:          []string{"blue-2"},
+		},
+		"selector matches no namespaces": {
+			tenant:   "green",
+			expected: []string{},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r := New(fake.NewClientBuilder().WithObjects(objects...).Build(), nil, nil,
+				tlogr.NewTestLogger(t), WithWorkingNamespaces(tc.workingNamespaces))
+
+			cr := newRegistration("reg", "kuards.extensions.triggermesh.io", time.Now(), tc.tenant)
+			namespaces, err := r.selectNamespaces(context.Background(), cr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, namespaces)
+		})
+	}
+}
+
+func TestOverlappingRegistration(t *testing.T) {
+	const tCRD = "kuards.extensions.triggermesh.io"
+	older := time.Now().Add(-time.Hour)
+	newer := time.Now()
+
+	testCases := map[string]struct {
+		existing *scobyv1alpha1.CRDRegistration
+		current  *scobyv1alpha1.CRDRegistration
+		expected string
+	}{
+		"older registration for all namespaces": {
+			existing: newRegistration("existing", tCRD, older, ""),
+			current:  newRegistration("current", tCRD, newer, "blue"),
+			expected: "existing",
+		},
+		"older registration at overlapping namespaces": {
+			existing: newRegistration("existing", tCRD, older, "blue"),
+			current:  newRegistration("current", tCRD, newer, "blue"),
+			expected: "existing",
+		},
+		"older registration at other namespaces": {
+			existing: newRegistration("existing", tCRD, older, "red"),
+			current:  newRegistration("current", tCRD, newer, "blue"),
+			expected: "",
+		},
+		"newer registration at overlapping namespaces": {
+			existing: newRegistration("existing", tCRD, newer, "blue"),
+			current:  newRegistration("current", tCRD, older, "blue"),
+			expected: "",
+		},
+		"same creation time": {
+			existing: newRegistration("a-existing", tCRD, older, "blue"),
+			current:  newRegistration("b-current", tCRD, older, "blue"),
+			expected: "a-existing",
+		},
+		"other CRD": {
+			existing: newRegistration("existing", "other.extensions.triggermesh.io", older, ""),
+			current:  newRegistration("current", tCRD, newer, ""),
+			expected: "",
+		},
+	}
+
+	s := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(s))
+	require.NoError(t, scobyv1alpha1.AddToScheme(s))
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(s).WithObjects(
+				newNamespace("blue-1", "blue"),
+				newNamespace("red-1", "red"),
+				tc.existing,
+				tc.current,
+			).Build()
+			r := New(c, nil, nil, tlogr.NewTestLogger(t))
+
+			ctx := context.Background()
+			namespaces, err := r.selectNamespaces(ctx, tc.current)
+			require.NoError(t, err)
+
+			other, err := r.overlappingRegistration(ctx, tc.current, namespaces)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, other)
+		})
+	}
+}
@@ -5,11 +5,13 @@ package crd
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/go-logr/logr"
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/types"
 
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -26,6 +28,11 @@ import (
 
 const (
 	crdFinalizer = "scoby.triggermesh.io/finalizer"
+
+	// componentResyncPeriod is the period for refreshing the component
+	// summary at the registration status. Instance counters are not
+	// published on every instance change to avoid flooding the API server.
+	componentResyncPeriod = 30 * time.Second
 )
 
 //+kubebuilder:rbac:groups=scoby.triggermesh.io,resources=crdregistrations,verbs=get;list;watch;create;update;patch;delete
@@ -37,17 +44,38 @@ type Reconciler struct {
 	registry registry.ComponentRegistry
 	resolver resolver.Resolver
 
+	// workingNamespaces restricts the namespaces that registrations
+	// can select.
+	workingNamespaces []string
+
 	log    logr.Logger
 	client client.Client
 }
 
-func New(client client.Client, registry registry.ComponentRegistry, resolver resolver.Resolver, log logr.Logger) *Reconciler {
-	return &Reconciler{
+// ReconcilerOption sets optional parameters for the reconciler.
+type ReconcilerOption func(*Reconciler)
+
+// WithWorkingNamespaces restricts namespace selectors at registrations
+// to the namespaces the controller works with.
+func WithWorkingNamespaces(namespaces []string) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.workingNamespaces = namespaces
+	}
+}
+
+func New(client client.Client, registry registry.ComponentRegistry, resolver resolver.Resolver, log logr.Logger, opts ...ReconcilerOption) *Reconciler {
+	r := &Reconciler{
 		log:      log,
 		client:   client,
 		registry: registry,
 		resolver: resolver,
 	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
 }
 
 func (r *Reconciler) On(ctx context.Context, req reconcile.Request) (ctrl.Result, error) {
@@ -75,7 +103,8 @@ func (r *Reconciler) On(ctx context.Context, req reconcile.Request) (ctrl.Result
 	//
 	// We need to compare the internal status, which is covered by the semantic
 	// comparer library
-	if !semantic.Semantic.DeepEqual(&cr.Status.Status, &existing.Status.Status) {
+	if !semantic.Semantic.DeepEqual(&cr.Status.Status, &existing.Status.Status) ||
+		!equality.Semantic.DeepEqual(cr.Status.Component, existing.Status.Component) {
 		// The err variable is newly defined, if the update is unsuccessful
 		// the error returned will be the update operation error.
 		if err := r.client.Status().Update(ctx, cr); err != nil {
@@ -111,7 +140,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (ctrl
 	//
 	// We need to compare the internal status, which is covered by the semantic
 	// comparer library
-	if !semantic.Semantic.DeepEqual(&cr.Status.Status, &existing.Status.Status) {
+	if !semantic.Semantic.DeepEqual(&cr.Status.Status, &existing.Status.Status) ||
+		!equality.Semantic.DeepEqual(cr.Status.Component, existing.Status.Component) {
 		// The err variable is newly defined, if the update is unsuccessful
 		// the error returned will be the update operation error.
 		if err := r.client.Status().Update(ctx, cr); err != nil {
@@ -152,8 +182,16 @@ func (r *Reconciler) reconcileRegistration(ctx context.Context, cr *scobyv1alpha
 	sm := cr.GetStatusManager()
 	sm.SetObservedGeneration(cr.Generation)
 
+	// Generate the CRD when the registration owns it.
+	if cr.Spec.Generate != nil {
+		if err := r.reconcileGeneratedCRD(ctx, cr); err != nil {
+			sm.MarkConditionFalse(scobyv1alpha1.CRDRegistrationConditionCRDExists, "CRDGENERATIONFAILED", err.Error())
+			return ctrl.Result{}, err
+		}
+	}
+
 	// Lookup the CRD for the registration.
-	key := types.NamespacedName{Name: cr.Spec.CRD}
+	key := types.NamespacedName{Name: cr.GetCRDName()}
 	crd := &apiextensionsv1.CustomResourceDefinition{}
 	if err := r.client.Get(ctx, key, crd, &client.GetOptions{}); err != nil {
 		sm.MarkConditionFalse(scobyv1alpha1.CRDRegistrationConditionCRDExists, "CRDERROR", err.Error())
@@ -184,14 +222,50 @@ func (r *Reconciler) reconcileRegistration(ctx context.Context, cr *scobyv1alpha
 		sm.SetAnnotation(commonv1alpha1.CRDRegistrationAnnotationHookURL, *u)
 	}
 
+	// Select namespaces and make sure that no other registration for the
+	// same CRD is managing them.
+	namespaces, err := r.selectNamespaces(ctx, cr)
+	if err != nil {
+		r.registry.RemoveComponentController(cr)
+		sm.MarkConditionFalse(scobyv1alpha1.CRDRegistrationConditionControllerReady,
+			"NAMESPACESFAILED", err.Error())
+		cr.Status.Component = nil
+		return ctrl.Result{}, err
+	}
+
+	if namespaces != nil && len(namespaces) == 0 {
+		r.registry.RemoveComponentController(cr)
+		sm.MarkConditionFalse(scobyv1alpha1.CRDRegistrationConditionControllerReady,
+			"NONAMESPACES", "No namespaces match the namespace selector")
+		cr.Status.Component = nil
+		return ctrl.Result{RequeueAfter: componentResyncPeriod}, nil
+	}
+
+	other, err := r.overlappingRegistration(ctx, cr, namespaces)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if other != "" {
+		r.registry.RemoveComponentController(cr)
+		sm.MarkConditionFalse(scobyv1alpha1.CRDRegistrationConditionControllerReady,
+			"OVERLAPPINGREGISTRATION",
+			fmt.Sprintf("Registration %s manages the same CRD at overlapping namespaces", other))
+		cr.Status.Component = nil
+		return ctrl.Result{RequeueAfter: componentResyncPeriod}, nil
+	}
+
 	// Make sure the CRD controller is running
-	err := r.registry.EnsureComponentController(cr, crd)
+	err = r.registry.EnsureComponentController(cr, crd, namespaces)
 	if err != nil {
 		sm.MarkConditionFalse(scobyv1alpha1.CRDRegistrationConditionControllerReady,
 			"CONTROLLERFAILED", err.Error())
+		cr.Status.Component = r.registry.GetComponentStatus(cr)
+		return ctrl.Result{}, err
 	}
 
 	sm.MarkConditionTrue(scobyv1alpha1.CRDRegistrationConditionControllerReady, "CONTROLLERSTARTED")
 
-	return ctrl.Result{}, err
+	cr.Status.Component = r.registry.GetComponentStatus(cr)
+
+	return ctrl.Result{RequeueAfter: componentResyncPeriod}, nil
 }
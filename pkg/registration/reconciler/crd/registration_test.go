@@ -0,0 +1,77 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package crd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	tlogr "github.com/go-logr/logr/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	commonv1alpha1 "github.com/triggermesh/scoby/pkg/apis/common/v1alpha1"
+	scobyv1alpha1 "github.com/triggermesh/scoby/pkg/apis/scoby/v1alpha1"
+)
+
+type failingRegistry struct {
+	err error
+}
+
+func (r *failingRegistry) EnsureComponentController(commonv1alpha1.Registration, *apiextensionsv1.CustomResourceDefinition, []string) error {
+	return r.err
+}
+
+func (r *failingRegistry) RemoveComponentController(commonv1alpha1.Registration) {}
+
+func (r *failingRegistry) GetComponentStatus(commonv1alpha1.Registration) *commonv1alpha1.ComponentStatus {
+	return nil
+}
+
+func (r *failingRegistry) WaitStopChannel() <-chan error {
+	return nil
+}
+
+func TestReconcileControllerFailed(t *testing.T) {
+	const tCRD = "kuards.extensions.triggermesh.io"
+
+	s := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(s))
+	require.NoError(t, apiextensionsv1.AddToScheme(s))
+	require.NoError(t, scobyv1alpha1.AddToScheme(s))
+
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: tCRD},
+	}
+
+	cr := newRegistration("reg", tCRD, time.Now(), "")
+	cr.Finalizers = []string{crdFinalizer}
+
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(crd, cr).Build()
+	r := New(c, &failingRegistry{err: errors.New("controller error")}, nil, tlogr.NewTestLogger(t))
+
+	_, err := r.reconcileRegistration(context.Background(), cr)
+	require.EqualError(t, err, "controller error")
+
+	var ready *commonv1alpha1.Condition
+	for i := range cr.Status.Conditions {
+		if cr.Status.Conditions[i].Type == scobyv1alpha1.CRDRegistrationConditionControllerReady {
+			ready = &cr.Status.Conditions[i]
+		}
+	}
+
+	require.NotNil(t, ready)
+	assert.Equal(t, metav1.ConditionFalse, ready.Status)
+	assert.Equal(t, "CONTROLLERFAILED", ready.Reason)
+	assert.Equal(t, "controller error", ready.Message)
+}
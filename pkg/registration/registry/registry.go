@@ -14,6 +14,7 @@ import (
 	"github.com/go-logr/logr"
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 
 	commonv1alpha1 "github.com/triggermesh/scoby/pkg/apis/common/v1alpha1"
 	"github.com/triggermesh/scoby/pkg/component/builder"
@@ -26,14 +27,15 @@ const (
 // ComponentRegistry keeps track of the controllers created
 // for each registered component.
 type ComponentRegistry interface {
-	EnsureComponentController(reg commonv1alpha1.Registration, crd *apiextensionsv1.CustomResourceDefinition) error
+	EnsureComponentController(reg commonv1alpha1.Registration, crd *apiextensionsv1.CustomResourceDefinition, namespaces []string) error
 	RemoveComponentController(reg commonv1alpha1.Registration)
+	GetComponentStatus(reg commonv1alpha1.Registration) *commonv1alpha1.ComponentStatus
 	WaitStopChannel() <-chan error
 }
 
 type entry struct {
-	reconcilerCh chan error
-	cancel       context.CancelFunc
+	component *builder.Component
+	cancel    context.CancelFunc
 }
 
 type componentRegistry struct {
@@ -84,7 +86,7 @@ func New(ctx context.Context, crb builder.Builder, logger *logr.Logger) Componen
 				c.cancel()
 
 				select {
-				case err := <-c.reconcilerCh:
+				case err := <-c.component.Stopped:
 					if err != nil {
 						errs = append(errs, fmt.Sprintf("%s: %v", name, err))
 					}
@@ -105,7 +107,10 @@ func New(ctx context.Context, crb builder.Builder, logger *logr.Logger) Componen
 	return cr
 }
 
-func (cr *componentRegistry) EnsureComponentController(reg commonv1alpha1.Registration, crd *apiextensionsv1.CustomResourceDefinition) error {
+// EnsureComponentController starts the controller for the registration if
+// it is not running. Namespaces restrict the controller, when empty all
+// namespaces are reconciled.
+func (cr *componentRegistry) EnsureComponentController(reg commonv1alpha1.Registration, crd *apiextensionsv1.CustomResourceDefinition, namespaces []string) error {
 	cr.logger.V(1).Info("EnsureComponentController", "crd", crd.Name)
 
 	cr.lock.Lock()
@@ -115,23 +120,32 @@ func (cr *componentRegistry) EnsureComponentController(reg commonv1alpha1.Regist
 		return fmt.Errorf("component registry is closing")
 	}
 
-	_, found := cr.controllers[reg.GetName()]
-	if found {
-		return nil
+	if e, found := cr.controllers[reg.GetName()]; found {
+		// Controllers are restarted when the CRD version to be served
+		// changes, for example when a new version is added, or when
+		// the namespaces they are restricted to change.
+		crdv := builder.SelectCRDVersion(crd, reg)
+		if (crdv == nil || crdv.Name == e.component.CRDVersion()) &&
+			equality.Semantic.DeepEqual(namespaces, e.component.Namespaces()) {
+			return nil
+		}
+
+		cr.logger.Info("Restarting component controller", "name", crd.Name)
+		cr.stopComponentController(reg.GetName(), e)
 	}
 
 	cr.logger.Info("Creating component controller for CRD", "name", crd.Name)
 
 	ctx, cancel := context.WithCancel(cr.context)
-	rch, err := cr.crb.StartNewReconciler(ctx, crd, reg)
+	c, err := cr.crb.StartNewReconciler(ctx, crd, reg, namespaces)
 	if err != nil {
 		cancel()
 		return err
 	}
 
 	cr.controllers[reg.GetName()] = &entry{
-		reconcilerCh: rch,
-		cancel:       cancel,
+		component: c,
+		cancel:    cancel,
 	}
 	return nil
 }
@@ -152,24 +166,43 @@ func (cr *componentRegistry) RemoveComponentController(reg commonv1alpha1.Regist
 		// TODO remove also the underlying informers.
 		// depends on: https://github.com/kubernetes-sigs/controller-runtime/pull/2159
 
-		var err error
-		entry.cancel()
-		select {
-		case err = <-entry.reconcilerCh:
-			if err != nil {
-				cr.logger.Error(err, "controller stop returned an error", "controller", rn)
-			}
-		case <-time.After(registryGracefulTimeout):
-			cr.logger.Error(errors.New("controller stop timed out"), "controller", rn)
-		}
-
-		delete(cr.controllers, rn)
+		cr.stopComponentController(rn, entry)
 	} else {
 		cr.logger.Info("Component Controller does not exists. Skipping removal", "registration", rn)
 		return
 	}
 }
 
+// stopComponentController stops the controller and removes it from the
+// registry. Must be called holding the lock.
+func (cr *componentRegistry) stopComponentController(name string, e *entry) {
+	e.cancel()
+	select {
+	case err := <-e.component.Stopped:
+		if err != nil {
+			cr.logger.Error(err, "controller stop returned an error", "controller", name)
+		}
+	case <-time.After(registryGracefulTimeout):
+		cr.logger.Error(errors.New("timed out"), "controller stop timed out", "controller", name)
+	}
+
+	delete(cr.controllers, name)
+}
+
+// GetComponentStatus returns the summary of the controller for the
+// registration, or nil if it is not running.
+func (cr *componentRegistry) GetComponentStatus(reg commonv1alpha1.Registration) *commonv1alpha1.ComponentStatus {
+	cr.lock.RLock()
+	defer cr.lock.RUnlock()
+
+	entry, found := cr.controllers[reg.GetName()]
+	if !found {
+		return nil
+	}
+
+	return entry.component.Status()
+}
+
 func (cr *componentRegistry) WaitStopChannel() <-chan error {
 	return cr.stoCh
 }
@@ -0,0 +1,176 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package diagnostics summarizes failures at the objects that run a
+// workload, so that they can be informed at the controlled object status.
+package diagnostics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// maxMessageLength limits the size of messages taken from containers.
+const maxMessageLength = 256
+
+// Failure is the most relevant failure found at the workload.
+type Failure struct {
+	// Reason is the identifier of the failure, like ImagePullBackOff,
+	// CrashLoopBackOff, OOMKilled or Unschedulable.
+	Reason string
+	// Message is a human readable summary of the failure.
+	Message string
+}
+
+// waiting container reasons sorted from most to less relevant.
+var waitingReasons = map[string]int{
+	"ErrImagePull":               0,
+	"ImagePullBackOff":           0,
+	"InvalidImageName":           0,
+	"CreateContainerConfigError": 1,
+	"CreateContainerError":       1,
+	"RunContainerError":          1,
+	"CrashLoopBackOff":           2,
+}
+
+const (
+	priorityTerminated    = 3
+	priorityUnschedulable = 4
+	priorityNone          = 100
+)
+
+// FromReplicaSets returns the failure informed by the ReplicaFailure
+// condition of any of the ReplicaSets, which is set when pods cannot
+// be created, for example due to quota.
+func FromReplicaSets(rss []appsv1.ReplicaSet) *Failure {
+	for i := range rss {
+		for _, c := range rss[i].Status.Conditions {
+			if c.Type == appsv1.ReplicaSetReplicaFailure && c.Status == corev1.ConditionTrue {
+				return &Failure{
+					Reason:  c.Reason,
+					Message: fmt.Sprintf("replicaset %s: %s", rss[i].Name, c.Message),
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// FromPods returns the most relevant failure at the pods. Image
+// pull errors take precedence over configuration errors, crash loops,
+// terminated containers and unschedulable pods.
+func FromPods(pods []corev1.Pod) *Failure {
+	// Sort to return consistent results when many pods fail.
+	sorted := make([]*corev1.Pod, 0, len(pods))
+	for i := range pods {
+		sorted = append(sorted, &pods[i])
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	var failure *Failure
+	priority := priorityNone
+
+	for _, p := range sorted {
+		if p.DeletionTimestamp != nil {
+			continue
+		}
+
+		f, pr := fromPod(p)
+		if f != nil && pr < priority {
+			failure, priority = f, pr
+		}
+	}
+
+	return failure
+}
+
+func fromPod(p *corev1.Pod) (*Failure, int) {
+	var failure *Failure
+	priority := priorityNone
+
+	statuses := append([]corev1.ContainerStatus{}, p.Status.InitContainerStatuses...)
+	statuses = append(statuses, p.Status.ContainerStatuses...)
+
+	for i := range statuses {
+		cs := &statuses[i]
+
+		if w := cs.State.Waiting; w != nil {
+			pr, ok := waitingReasons[w.Reason]
+			if !ok || pr >= priority {
+				continue
+			}
+
+			msg := fmt.Sprintf("pod %s container %q: %s", p.Name, cs.Name, w.Reason)
+			if w.Message != "" {
+				msg += ": " + truncate(w.Message)
+			}
+			if t := cs.LastTerminationState.Terminated; t != nil {
+				msg += ". Last termination: " + terminatedMessage(t)
+			}
+
+			failure, priority = &Failure{Reason: w.Reason, Message: msg}, pr
+			continue
+		}
+
+		if t := cs.State.Terminated; t != nil && t.ExitCode != 0 && priorityTerminated < priority {
+			reason := t.Reason
+			if reason == "" {
+				reason = "Error"
+			}
+			failure = &Failure{
+				Reason:  reason,
+				Message: fmt.Sprintf("pod %s container %q terminated: %s", p.Name, cs.Name, terminatedMessage(t)),
+			}
+			priority = priorityTerminated
+		}
+	}
+
+	if failure != nil || p.Status.Phase != corev1.PodPending {
+		return failure, priority
+	}
+
+	for _, c := range p.Status.Conditions {
+		if c.Type == corev1.PodScheduled && c.Status == corev1.ConditionFalse {
+			reason := c.Reason
+			if reason == "" {
+				reason = "Unschedulable"
+			}
+			return &Failure{
+				Reason:  reason,
+				Message: fmt.Sprintf("pod %s is pending: %s", p.Name, c.Message),
+			}, priorityUnschedulable
+		}
+	}
+
+	return nil, priorityNone
+}
+
+func terminatedMessage(t *corev1.ContainerStateTerminated) string {
+	reason := t.Reason
+	if reason == "" {
+		reason = "Error"
+	}
+
+	msg := fmt.Sprintf("%s (exit code %d)", reason, t.ExitCode)
+	if m := strings.TrimSpace(t.Message); m != "" {
+		msg += ": " + truncate(m)
+	}
+
+	return msg
+}
+
+func truncate(s string) string {
+	r := []rune(s)
+	if len(r) <= maxMessageLength {
+		return s
+	}
+
+	return string(r[:maxMessageLength-3]) + "..."
+}
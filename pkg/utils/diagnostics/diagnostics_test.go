@@ -0,0 +1,123 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package diagnostics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newPod(name string, phase corev1.PodPhase, statuses ...corev1.ContainerStatus) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.PodStatus{
+			Phase:             phase,
+			ContainerStatuses: statuses,
+		},
+	}
+}
+
+func TestFromPods(t *testing.T) {
+	imagePull := corev1.ContainerStatus{
+		Name: "adapter",
+		State: corev1.ContainerState{
+			Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: `Back-off pulling image "kuard:bad"`},
+		},
+	}
+
+	crashLoop := corev1.ContainerStatus{
+		Name: "adapter",
+		State: corev1.ContainerState{
+			Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+		},
+		LastTerminationState: corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137},
+		},
+	}
+
+	running := corev1.ContainerStatus{
+		Name: "adapter",
+		State: corev1.ContainerState{
+			Running: &corev1.ContainerStateRunning{},
+		},
+	}
+
+	unschedulable := newPod("pod-c", corev1.PodPending)
+	unschedulable.Status.Conditions = []corev1.PodCondition{{
+		Type:    corev1.PodScheduled,
+		Status:  corev1.ConditionFalse,
+		Reason:  "Unschedulable",
+		Message: "0/3 nodes are available: 3 Insufficient cpu.",
+	}}
+
+	testCases := map[string]struct {
+		pods     []corev1.Pod
+		expected *Failure
+	}{
+		"healthy": {
+			pods: []corev1.Pod{newPod("pod-a", corev1.PodRunning, running)},
+		},
+		"image pull": {
+			pods: []corev1.Pod{newPod("pod-a", corev1.PodPending, imagePull)},
+			expected: &Failure{
+				Reason:  "ImagePullBackOff",
+				Message: `pod pod-a container "adapter": ImagePullBackOff: Back-off pulling image "kuard:bad"`,
+			},
+		},
+		"crash loop with last termination": {
+			pods: []corev1.Pod{newPod("pod-a", corev1.PodRunning, crashLoop)},
+			expected: &Failure{
+				Reason:  "CrashLoopBackOff",
+				Message: `pod pod-a container "adapter": CrashLoopBackOff. Last termination: OOMKilled (exit code 137)`,
+			},
+		},
+		"unschedulable": {
+			pods: []corev1.Pod{unschedulable},
+			expected: &Failure{
+				Reason:  "Unschedulable",
+				Message: "pod pod-c is pending: 0/3 nodes are available: 3 Insufficient cpu.",
+			},
+		},
+		"most relevant failure": {
+			pods: []corev1.Pod{
+				unschedulable,
+				newPod("pod-b", corev1.PodRunning, crashLoop),
+				newPod("pod-a", corev1.PodPending, imagePull),
+			},
+			expected: &Failure{
+				Reason:  "ImagePullBackOff",
+				Message: `pod pod-a container "adapter": ImagePullBackOff: Back-off pulling image "kuard:bad"`,
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, FromPods(tc.pods))
+		})
+	}
+}
+
+func TestFromReplicaSets(t *testing.T) {
+	rs := appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "rs-a"},
+		Status: appsv1.ReplicaSetStatus{
+			Conditions: []appsv1.ReplicaSetCondition{{
+				Type:    appsv1.ReplicaSetReplicaFailure,
+				Status:  corev1.ConditionTrue,
+				Reason:  "FailedCreate",
+				Message: "exceeded quota",
+			}},
+		},
+	}
+
+	assert.Nil(t, FromReplicaSets([]appsv1.ReplicaSet{{}}))
+	assert.Equal(t, &Failure{Reason: "FailedCreate", Message: "replicaset rs-a: exceeded quota"},
+		FromReplicaSets([]appsv1.ReplicaSet{rs}))
+}
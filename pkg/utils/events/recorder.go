@@ -0,0 +1,113 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package events contains helpers to emit Kubernetes events.
+package events
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// DefaultInterval is the minimum interval between identical events
+// for the same object.
+const DefaultInterval = 5 * time.Minute
+
+type eventKey struct {
+	object    string
+	eventtype string
+	reason    string
+	message   string
+}
+
+// rateLimitedRecorder drops events that are identical to one emitted
+// for the same object within the interval. Reconciliations that keep
+// failing the same way are informed once per interval.
+type rateLimitedRecorder struct {
+	recorder record.EventRecorder
+	interval time.Duration
+	now      func() time.Time
+
+	sent map[eventKey]time.Time
+	m    sync.Mutex
+}
+
+var _ record.EventRecorder = (*rateLimitedRecorder)(nil)
+
+// NewRateLimitedRecorder wraps the event recorder, dropping repeated
+// events for the same object within the interval.
+func NewRateLimitedRecorder(recorder record.EventRecorder, interval time.Duration) record.EventRecorder {
+	return &rateLimitedRecorder{
+		recorder: recorder,
+		interval: interval,
+		now:      time.Now,
+		sent:     make(map[eventKey]time.Time),
+	}
+}
+
+func (r *rateLimitedRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	if !r.allow(object, eventtype, reason, message) {
+		return
+	}
+	r.recorder.Event(object, eventtype, reason, message)
+}
+
+func (r *rateLimitedRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *rateLimitedRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	message := fmt.Sprintf(messageFmt, args...)
+	if !r.allow(object, eventtype, reason, message) {
+		return
+	}
+	r.recorder.AnnotatedEventf(object, annotations, eventtype, reason, "%s", message)
+}
+
+// allow returns true if the event was not sent within the interval.
+func (r *rateLimitedRecorder) allow(object runtime.Object, eventtype, reason, message string) bool {
+	key := eventKey{
+		object:    objectID(object),
+		eventtype: eventtype,
+		reason:    reason,
+		message:   message,
+	}
+
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	now := r.now()
+
+	// Forget expired events so that the map does not grow
+	// with objects that no longer exist.
+	for k, t := range r.sent {
+		if now.Sub(t) >= r.interval {
+			delete(r.sent, k)
+		}
+	}
+
+	if _, ok := r.sent[key]; ok {
+		return false
+	}
+
+	r.sent[key] = now
+	return true
+}
+
+func objectID(object runtime.Object) string {
+	m, err := meta.Accessor(object)
+	if err != nil {
+		return fmt.Sprintf("%p", object)
+	}
+
+	if uid := m.GetUID(); uid != "" {
+		return string(uid)
+	}
+
+	return m.GetNamespace() + "/" + m.GetName()
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestRateLimitedRecorder(t *testing.T) {
+	fr := record.NewFakeRecorder(10)
+	r := NewRateLimitedRecorder(fr, time.Minute).(*rateLimitedRecorder)
+
+	now := time.Now()
+	r.now = func() time.Time { return now }
+
+	a := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "a", UID: "uid-a"}}
+	b := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "b", UID: "uid-b"}}
+
+	r.Eventf(a, corev1.EventTypeWarning, "Failed", "failed %d", 1)
+	r.Eventf(a, corev1.EventTypeWarning, "Failed", "failed %d", 1)
+	r.Eventf(a, corev1.EventTypeWarning, "Failed", "failed %d", 2)
+	r.Eventf(b, corev1.EventTypeWarning, "Failed", "failed %d", 1)
+
+	now = now.Add(time.Minute)
+	r.Eventf(a, corev1.EventTypeWarning, "Failed", "failed %d", 1)
+
+	close(fr.Events)
+	var got []string
+	for e := range fr.Events {
+		got = append(got, e)
+	}
+
+	assert.Equal(t, []string{
+		"Warning Failed failed 1",
+		"Warning Failed failed 2",
+		"Warning Failed failed 1",
+		"Warning Failed failed 1",
+	}, got)
+	assert.Len(t, r.sent, 1, "expired events should be forgotten")
+}
@@ -110,6 +110,18 @@ func ContainerAddArgs(s string) ContainerOption {
 	}
 }
 
+func ContainerWithCommand(command ...string) ContainerOption {
+	return func(c *corev1.Container) {
+		c.Command = command
+	}
+}
+
+func ContainerWithArgs(args ...string) ContainerOption {
+	return func(c *corev1.Container) {
+		c.Args = args
+	}
+}
+
 func ContainerAddPort(name string, containerPort int32) ContainerOption {
 	return func(c *corev1.Container) {
 		if c.Ports == nil {
@@ -122,6 +134,59 @@ func ContainerAddPort(name string, containerPort int32) ContainerOption {
 	}
 }
 
+// ContainerAddPorts adds ports to the container. Ports whose number
+// is already declared are not added.
+func ContainerAddPorts(ports ...corev1.ContainerPort) ContainerOption {
+	return func(c *corev1.Container) {
+		for _, p := range ports {
+			if ContainerHasPort(c, p.ContainerPort) {
+				continue
+			}
+			c.Ports = append(c.Ports, p)
+		}
+	}
+}
+
+// ContainerHasPort returns true if the port number is declared.
+func ContainerHasPort(c *corev1.Container, containerPort int32) bool {
+	for i := range c.Ports {
+		if c.Ports[i].ContainerPort == containerPort {
+			return true
+		}
+	}
+	return false
+}
+
+func ContainerWithResources(r *corev1.ResourceRequirements) ContainerOption {
+	return func(c *corev1.Container) {
+		c.Resources = *r
+	}
+}
+
+func ContainerWithLivenessProbe(p *corev1.Probe) ContainerOption {
+	return func(c *corev1.Container) {
+		c.LivenessProbe = p
+	}
+}
+
+func ContainerWithReadinessProbe(p *corev1.Probe) ContainerOption {
+	return func(c *corev1.Container) {
+		c.ReadinessProbe = p
+	}
+}
+
+func ContainerWithStartupProbe(p *corev1.Probe) ContainerOption {
+	return func(c *corev1.Container) {
+		c.StartupProbe = p
+	}
+}
+
+func ContainerWithLifecycle(l *corev1.Lifecycle) ContainerOption {
+	return func(c *corev1.Container) {
+		c.Lifecycle = l
+	}
+}
+
 func ContainerAddVolumeMount(vm *corev1.VolumeMount) ContainerOption {
 	return func(c *corev1.Container) {
 		if c.VolumeMounts == nil {
@@ -131,6 +196,12 @@ func ContainerAddVolumeMount(vm *corev1.VolumeMount) ContainerOption {
 	}
 }
 
+func ContainerWithImage(image string) ContainerOption {
+	return func(c *corev1.Container) {
+		c.Image = image
+	}
+}
+
 func ContainerWithImagePullPolicy(policy corev1.PullPolicy) ContainerOption {
 	return func(c *corev1.Container) {
 		c.ImagePullPolicy = policy
@@ -172,3 +243,24 @@ func SecurityContextWithReadOnlyRootFilesystem(ro bool) SecurityContextOption {
 		sc.ReadOnlyRootFilesystem = &ro
 	}
 }
+
+func SecurityContextWithRunAsNonRoot(nonRoot bool) SecurityContextOption {
+	return func(sc *corev1.SecurityContext) {
+		sc.RunAsNonRoot = &nonRoot
+	}
+}
+
+func SecurityContextWithDropCapabilities(caps ...corev1.Capability) SecurityContextOption {
+	return func(sc *corev1.SecurityContext) {
+		if sc.Capabilities == nil {
+			sc.Capabilities = &corev1.Capabilities{}
+		}
+		sc.Capabilities.Drop = append(sc.Capabilities.Drop, caps...)
+	}
+}
+
+func SecurityContextWithSeccompProfile(t corev1.SeccompProfileType) SecurityContextOption {
+	return func(sc *corev1.SecurityContext) {
+		sc.SeccompProfile = &corev1.SeccompProfile{Type: t}
+	}
+}
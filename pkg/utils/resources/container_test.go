@@ -42,6 +42,17 @@ func TestNewContainer(t *testing.T) {
 				Image: tImage,
 				Args:  []string{"--key1=value1", "--key2", "value2", "--porcelain"},
 			}},
+		"with command and args": {
+			options: []ContainerOption{
+				ContainerWithCommand("/bin/sh", "-c"),
+				ContainerWithArgs("echo hello"),
+			},
+			expected: corev1.Container{
+				Name:    tName,
+				Image:   tImage,
+				Command: []string{"/bin/sh", "-c"},
+				Args:    []string{"echo hello"},
+			}},
 		"with port": {
 			options: []ContainerOption{
 				ContainerAddPort("port8888", 8888),
@@ -61,6 +72,35 @@ func TestNewContainer(t *testing.T) {
 					},
 				},
 			}},
+		"with ports not repeated": {
+			options: []ContainerOption{
+				ContainerAddPorts(
+					corev1.ContainerPort{Name: "http", ContainerPort: 8080},
+					corev1.ContainerPort{Name: "metrics", ContainerPort: 9090},
+				),
+				ContainerAddPorts(corev1.ContainerPort{ContainerPort: 8080}),
+			},
+			expected: corev1.Container{
+				Name:  tName,
+				Image: tImage,
+				Ports: []corev1.ContainerPort{
+					{Name: "http", ContainerPort: 8080},
+					{Name: "metrics", ContainerPort: 9090},
+				},
+			}},
+		"with probes": {
+			options: []ContainerOption{
+				ContainerWithLivenessProbe(&corev1.Probe{PeriodSeconds: 10}),
+				ContainerWithReadinessProbe(&corev1.Probe{PeriodSeconds: 5}),
+				ContainerWithStartupProbe(&corev1.Probe{FailureThreshold: 30}),
+			},
+			expected: corev1.Container{
+				Name:           tName,
+				Image:          tImage,
+				LivenessProbe:  &corev1.Probe{PeriodSeconds: 10},
+				ReadinessProbe: &corev1.Probe{PeriodSeconds: 5},
+				StartupProbe:   &corev1.Probe{FailureThreshold: 30},
+			}},
 		"with volume mount": {
 			options: []ContainerOption{
 				ContainerAddVolumeMount(
@@ -169,6 +209,30 @@ func TestNewContainer(t *testing.T) {
 					ReadOnlyRootFilesystem:   &tTrue,
 				},
 			}},
+		"with restricted security context": {
+			options: []ContainerOption{
+				ContainerWithSecurityContext(
+					NewSecurityContext(
+						SecurityContextWithPrivilegeEscalation(false),
+						SecurityContextWithRunAsNonRoot(true),
+						SecurityContextWithDropCapabilities("ALL"),
+						SecurityContextWithSeccompProfile(corev1.SeccompProfileTypeRuntimeDefault),
+					)),
+			},
+			expected: corev1.Container{
+				Name:  tName,
+				Image: tImage,
+				SecurityContext: &corev1.SecurityContext{
+					AllowPrivilegeEscalation: &tFalse,
+					RunAsNonRoot:             &tTrue,
+					Capabilities: &corev1.Capabilities{
+						Drop: []corev1.Capability{"ALL"},
+					},
+					SeccompProfile: &corev1.SeccompProfile{
+						Type: corev1.SeccompProfileTypeRuntimeDefault,
+					},
+				},
+			}},
 	}
 
 	for name, tc := range testCases {
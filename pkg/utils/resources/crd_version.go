@@ -66,3 +66,20 @@ func NewCRDVersion(version string, served, storage bool, spec *apiextensionsv1.J
 
 	return v, err
 }
+
+// CRDVersionWithStatusSchema replaces the default status schema, which
+// preserves unknown fields.
+func CRDVersionWithStatusSchema(status *apiextensionsv1.JSONSchemaProps) CRDVersionOption {
+	return func(v *apiextensionsv1.CustomResourceDefinitionVersion) error {
+		v.Schema.OpenAPIV3Schema.Properties["status"] = *status
+		return nil
+	}
+}
+
+// CRDVersionWithPrinterColumns replaces the default printer columns.
+func CRDVersionWithPrinterColumns(columns ...apiextensionsv1.CustomResourceColumnDefinition) CRDVersionOption {
+	return func(v *apiextensionsv1.CustomResourceDefinitionVersion) error {
+		v.AdditionalPrinterColumns = columns
+		return nil
+	}
+}
@@ -106,6 +106,12 @@ func PodSpecAddContainer(c *corev1.Container) PodSpecOption {
 	}
 }
 
+func PodSpecAddInitContainer(c *corev1.Container) PodSpecOption {
+	return func(ps *corev1.PodSpec) {
+		ps.InitContainers = append(ps.InitContainers, *c)
+	}
+}
+
 func PodSpecAddVolume(v *corev1.Volume) PodSpecOption {
 	return func(ps *corev1.PodSpec) {
 		if ps.Volumes == nil {
@@ -115,6 +121,50 @@ func PodSpecAddVolume(v *corev1.Volume) PodSpecOption {
 	}
 }
 
+func PodSpecAddImagePullSecrets(names ...string) PodSpecOption {
+	return func(ps *corev1.PodSpec) {
+		for _, n := range names {
+			ps.ImagePullSecrets = append(ps.ImagePullSecrets, corev1.LocalObjectReference{Name: n})
+		}
+	}
+}
+
+func PodSpecWithNodeSelector(selector map[string]string) PodSpecOption {
+	return func(ps *corev1.PodSpec) {
+		ps.NodeSelector = selector
+	}
+}
+
+func PodSpecWithAffinity(a *corev1.Affinity) PodSpecOption {
+	return func(ps *corev1.PodSpec) {
+		ps.Affinity = a
+	}
+}
+
+func PodSpecWithTolerations(tolerations ...corev1.Toleration) PodSpecOption {
+	return func(ps *corev1.PodSpec) {
+		ps.Tolerations = tolerations
+	}
+}
+
+func PodSpecWithTopologySpreadConstraints(constraints ...corev1.TopologySpreadConstraint) PodSpecOption {
+	return func(ps *corev1.PodSpec) {
+		ps.TopologySpreadConstraints = constraints
+	}
+}
+
+func PodSpecWithPriorityClassName(name string) PodSpecOption {
+	return func(ps *corev1.PodSpec) {
+		ps.PriorityClassName = name
+	}
+}
+
+func PodSpecWithSecurityContext(sc *corev1.PodSecurityContext) PodSpecOption {
+	return func(ps *corev1.PodSpec) {
+		ps.SecurityContext = sc
+	}
+}
+
 func PodSpecWithServiceAccountName(saName string) PodSpecOption {
 	return func(ps *corev1.PodSpec) {
 		ps.ServiceAccountName = saName
@@ -168,6 +168,101 @@ spec:
         ports:
         - name: myport
           containerPort: 12345
+`},
+		"with-image-pull-settings": {
+			options: []DeploymentOption{
+				DeploymentWithMetaOptions(MetaAddLabel("app", "controller-my-app")),
+				DeploymentAddSelectorForTemplate("app", "my-app"),
+				DeploymentSetReplicas(1),
+				DeploymentWithTemplateSpecOptions(
+					PodTemplateSpecWithPodSpecOptions(
+						PodSpecAddImagePullSecrets("registry-a", "registry-b"),
+						PodSpecAddContainer(
+							NewContainer("container-name", "my-image",
+								ContainerWithImage("my-image@sha256:abcd"),
+								ContainerWithImagePullPolicy(corev1.PullAlways))))),
+			},
+			expected: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: test-name
+  namespace: test-namespace
+  labels:
+    app: controller-my-app
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: my-app
+  template:
+    metadata:
+      labels:
+        app: my-app
+    spec:
+      imagePullSecrets:
+      - name: registry-a
+      - name: registry-b
+      containers:
+      - name: container-name
+        image: my-image@sha256:abcd
+        imagePullPolicy: Always
+`},
+		"with-scheduling": {
+			options: []DeploymentOption{
+				DeploymentWithMetaOptions(MetaAddLabel("app", "controller-my-app")),
+				DeploymentAddSelectorForTemplate("app", "my-app"),
+				DeploymentSetReplicas(1),
+				DeploymentWithTemplateSpecOptions(
+					PodTemplateSpecWithPodSpecOptions(
+						PodSpecWithNodeSelector(map[string]string{"disktype": "ssd"}),
+						PodSpecWithTolerations(corev1.Toleration{
+							Key:      "dedicated",
+							Operator: corev1.TolerationOpEqual,
+							Value:    "adapters",
+							Effect:   corev1.TaintEffectNoSchedule,
+						}),
+						PodSpecWithTopologySpreadConstraints(corev1.TopologySpreadConstraint{
+							MaxSkew:           1,
+							TopologyKey:       "topology.kubernetes.io/zone",
+							WhenUnsatisfiable: corev1.ScheduleAnyway,
+						}),
+						PodSpecWithPriorityClassName("high-priority"),
+						PodSpecAddContainer(NewContainer("container-name", "my-image")))),
+			},
+			expected: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: test-name
+  namespace: test-namespace
+  labels:
+    app: controller-my-app
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: my-app
+  template:
+    metadata:
+      labels:
+        app: my-app
+    spec:
+      nodeSelector:
+        disktype: ssd
+      tolerations:
+      - key: dedicated
+        operator: Equal
+        value: adapters
+        effect: NoSchedule
+      topologySpreadConstraints:
+      - maxSkew: 1
+        topologyKey: topology.kubernetes.io/zone
+        whenUnsatisfiable: ScheduleAnyway
+      priorityClassName: high-priority
+      containers:
+      - name: container-name
+        image: my-image
 `},
 		"with-pod-meta": {
 			options: []DeploymentOption{
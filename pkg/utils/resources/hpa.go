@@ -0,0 +1,80 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type HorizontalPodAutoscalerOption func(*autoscalingv2.HorizontalPodAutoscaler)
+
+func NewHorizontalPodAutoscaler(namespace, name string, opts ...HorizontalPodAutoscalerOption) *autoscalingv2.HorizontalPodAutoscaler {
+	meta := NewMeta(namespace, name)
+	h := &autoscalingv2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "HorizontalPodAutoscaler",
+			APIVersion: autoscalingv2.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: *meta,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+func HorizontalPodAutoscalerWithMetaOptions(opts ...MetaOption) HorizontalPodAutoscalerOption {
+	return func(h *autoscalingv2.HorizontalPodAutoscaler) {
+		for _, opt := range opts {
+			opt(&h.ObjectMeta)
+		}
+	}
+}
+
+// HorizontalPodAutoscalerForDeployment sets the deployment as the
+// scale target.
+func HorizontalPodAutoscalerForDeployment(name string) HorizontalPodAutoscalerOption {
+	return func(h *autoscalingv2.HorizontalPodAutoscaler) {
+		h.Spec.ScaleTargetRef = autoscalingv2.CrossVersionObjectReference{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "Deployment",
+			Name:       name,
+		}
+	}
+}
+
+func HorizontalPodAutoscalerWithReplicas(min *int32, max int32) HorizontalPodAutoscalerOption {
+	return func(h *autoscalingv2.HorizontalPodAutoscaler) {
+		h.Spec.MinReplicas = min
+		h.Spec.MaxReplicas = max
+	}
+}
+
+// HorizontalPodAutoscalerAddResourceUtilization adds a target average
+// utilization for a resource.
+func HorizontalPodAutoscalerAddResourceUtilization(resource corev1.ResourceName, utilization int32) HorizontalPodAutoscalerOption {
+	return func(h *autoscalingv2.HorizontalPodAutoscaler) {
+		h.Spec.Metrics = append(h.Spec.Metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: resource,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: &utilization,
+				},
+			},
+		})
+	}
+}
+
+func HorizontalPodAutoscalerAddMetrics(metrics ...autoscalingv2.MetricSpec) HorizontalPodAutoscalerOption {
+	return func(h *autoscalingv2.HorizontalPodAutoscaler) {
+		h.Spec.Metrics = append(h.Spec.Metrics, metrics...)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewHorizontalPodAutoscaler(t *testing.T) {
+	min := int32(2)
+	utilization := int32(80)
+
+	custom := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{Name: "queue_depth"},
+			Target: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType},
+		},
+	}
+
+	testCases := map[string]struct {
+		options  []HorizontalPodAutoscalerOption
+		expected autoscalingv2.HorizontalPodAutoscaler
+	}{
+		"basic": {
+			expected: autoscalingv2.HorizontalPodAutoscaler{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "HorizontalPodAutoscaler",
+					APIVersion: autoscalingv2.SchemeGroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: tNamespace,
+					Name:      tName,
+				},
+			}},
+		"with deployment target and metrics": {
+			options: []HorizontalPodAutoscalerOption{
+				HorizontalPodAutoscalerForDeployment(tName),
+				HorizontalPodAutoscalerWithReplicas(&min, 5),
+				HorizontalPodAutoscalerAddResourceUtilization(corev1.ResourceCPU, utilization),
+				HorizontalPodAutoscalerAddMetrics(custom),
+			},
+			expected: autoscalingv2.HorizontalPodAutoscaler{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "HorizontalPodAutoscaler",
+					APIVersion: autoscalingv2.SchemeGroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: tNamespace,
+					Name:      tName,
+				},
+				Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+						APIVersion: "apps/v1",
+						Kind:       "Deployment",
+						Name:       tName,
+					},
+					MinReplicas: &min,
+					MaxReplicas: 5,
+					Metrics: []autoscalingv2.MetricSpec{
+						{
+							Type: autoscalingv2.ResourceMetricSourceType,
+							Resource: &autoscalingv2.ResourceMetricSource{
+								Name: corev1.ResourceCPU,
+								Target: autoscalingv2.MetricTarget{
+									Type:               autoscalingv2.UtilizationMetricType,
+									AverageUtilization: &utilization,
+								},
+							},
+						},
+						custom,
+					},
+				},
+			}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := NewHorizontalPodAutoscaler(tNamespace, tName, tc.options...)
+			assert.Equal(t, &tc.expected, got)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// HTTPRouteGroupVersionKind is the Gateway API HTTPRoute kind.
+var HTTPRouteGroupVersionKind = schema.GroupVersionKind{
+	Group:   "gateway.networking.k8s.io",
+	Version: "v1",
+	Kind:    "HTTPRoute",
+}
+
+// HTTPRoute is the subset of the Gateway API HTTPRoute used to route
+// requests to services. Gateway API types are not a dependency, routes
+// are converted into unstructured objects to be managed.
+type HTTPRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec HTTPRouteSpec `json:"spec"`
+}
+
+type HTTPRouteSpec struct {
+	ParentRefs []HTTPRouteParentReference `json:"parentRefs,omitempty"`
+	Hostnames  []string                   `json:"hostnames,omitempty"`
+	Rules      []HTTPRouteRule            `json:"rules,omitempty"`
+}
+
+type HTTPRouteParentReference struct {
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name"`
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+type HTTPRouteRule struct {
+	Matches     []HTTPRouteMatch `json:"matches,omitempty"`
+	BackendRefs []HTTPBackendRef `json:"backendRefs,omitempty"`
+}
+
+type HTTPRouteMatch struct {
+	Path *HTTPPathMatch `json:"path,omitempty"`
+}
+
+type HTTPPathMatch struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type HTTPBackendRef struct {
+	Name string `json:"name"`
+	Port int32  `json:"port"`
+}
+
+type HTTPRouteOption func(*HTTPRoute)
+
+func NewHTTPRoute(namespace, name string, opts ...HTTPRouteOption) *HTTPRoute {
+	meta := NewMeta(namespace, name)
+	r := &HTTPRoute{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       HTTPRouteGroupVersionKind.Kind,
+			APIVersion: HTTPRouteGroupVersionKind.GroupVersion().String(),
+		},
+		ObjectMeta: *meta,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+func HTTPRouteWithMetaOptions(opts ...MetaOption) HTTPRouteOption {
+	return func(r *HTTPRoute) {
+		for _, opt := range opts {
+			opt(&r.ObjectMeta)
+		}
+	}
+}
+
+func HTTPRouteAddParentRef(namespace, name, sectionName string) HTTPRouteOption {
+	return func(r *HTTPRoute) {
+		r.Spec.ParentRefs = append(r.Spec.ParentRefs, HTTPRouteParentReference{
+			Namespace:   namespace,
+			Name:        name,
+			SectionName: sectionName,
+		})
+	}
+}
+
+func HTTPRouteAddHostname(hostname string) HTTPRouteOption {
+	return func(r *HTTPRoute) {
+		r.Spec.Hostnames = append(r.Spec.Hostnames, hostname)
+	}
+}
+
+// HTTPRouteAddRuleForService routes requests for the path prefix
+// to the service port.
+func HTTPRouteAddRuleForService(path, service string, port int32) HTTPRouteOption {
+	return func(r *HTTPRoute) {
+		r.Spec.Rules = append(r.Spec.Rules, HTTPRouteRule{
+			Matches: []HTTPRouteMatch{{
+				Path: &HTTPPathMatch{
+					Type:  "PathPrefix",
+					Value: path,
+				},
+			}},
+			BackendRefs: []HTTPBackendRef{{
+				Name: service,
+				Port: port,
+			}},
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewHTTPRoute(t *testing.T) {
+	testCases := map[string]struct {
+		options  []HTTPRouteOption
+		expected HTTPRoute
+	}{
+		"basic": {
+			expected: HTTPRoute{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "HTTPRoute",
+					APIVersion: "gateway.networking.k8s.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: tNamespace,
+					Name:      tName,
+				},
+			}},
+		"with rule": {
+			options: []HTTPRouteOption{
+				HTTPRouteAddParentRef("gateways", "public", "https"),
+				HTTPRouteAddHostname("test.example.com"),
+				HTTPRouteAddRuleForService("/hook", "svc", 8080),
+			},
+			expected: HTTPRoute{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "HTTPRoute",
+					APIVersion: "gateway.networking.k8s.io/v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: tNamespace,
+					Name:      tName,
+				},
+				Spec: HTTPRouteSpec{
+					ParentRefs: []HTTPRouteParentReference{{
+						Namespace:   "gateways",
+						Name:        "public",
+						SectionName: "https",
+					}},
+					Hostnames: []string{"test.example.com"},
+					Rules: []HTTPRouteRule{{
+						Matches: []HTTPRouteMatch{{
+							Path: &HTTPPathMatch{Type: "PathPrefix", Value: "/hook"},
+						}},
+						BackendRefs: []HTTPBackendRef{{Name: "svc", Port: 8080}},
+					}},
+				},
+			}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := NewHTTPRoute(tNamespace, tName, tc.options...)
+			assert.Equal(t, &tc.expected, got)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type IngressOption func(*networkingv1.Ingress)
+
+func NewIngress(namespace, name string, opts ...IngressOption) *networkingv1.Ingress {
+	meta := NewMeta(namespace, name)
+	i := &networkingv1.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Ingress",
+			APIVersion: networkingv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: *meta,
+	}
+
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	return i
+}
+
+func IngressWithMetaOptions(opts ...MetaOption) IngressOption {
+	return func(i *networkingv1.Ingress) {
+		for _, opt := range opts {
+			opt(&i.ObjectMeta)
+		}
+	}
+}
+
+func IngressWithClassName(className *string) IngressOption {
+	return func(i *networkingv1.Ingress) {
+		i.Spec.IngressClassName = className
+	}
+}
+
+// IngressAddRuleForService routes requests for the host and path prefix
+// to the service port.
+func IngressAddRuleForService(host, path, service string, port int32) IngressOption {
+	return func(i *networkingv1.Ingress) {
+		pt := networkingv1.PathTypePrefix
+		i.Spec.Rules = append(i.Spec.Rules, networkingv1.IngressRule{
+			Host: host,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{{
+						Path:     path,
+						PathType: &pt,
+						Backend: networkingv1.IngressBackend{
+							Service: &networkingv1.IngressServiceBackend{
+								Name: service,
+								Port: networkingv1.ServiceBackendPort{
+									Number: port,
+								},
+							},
+						},
+					}},
+				},
+			},
+		})
+	}
+}
+
+func IngressAddTLS(secretName string, hosts ...string) IngressOption {
+	return func(i *networkingv1.Ingress) {
+		i.Spec.TLS = append(i.Spec.TLS, networkingv1.IngressTLS{
+			Hosts:      hosts,
+			SecretName: secretName,
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewIngress(t *testing.T) {
+	className := "nginx"
+	pathType := networkingv1.PathTypePrefix
+
+	testCases := map[string]struct {
+		options  []IngressOption
+		expected networkingv1.Ingress
+	}{
+		"basic": {
+			expected: networkingv1.Ingress{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Ingress",
+					APIVersion: networkingv1.SchemeGroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: tNamespace,
+					Name:      tName,
+				},
+			}},
+		"with rule and tls": {
+			options: []IngressOption{
+				IngressWithClassName(&className),
+				IngressAddRuleForService("test.example.com", "/", "svc", 80),
+				IngressAddTLS("cert", "test.example.com"),
+			},
+			expected: networkingv1.Ingress{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Ingress",
+					APIVersion: networkingv1.SchemeGroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: tNamespace,
+					Name:      tName,
+				},
+				Spec: networkingv1.IngressSpec{
+					IngressClassName: &className,
+					Rules: []networkingv1.IngressRule{{
+						Host: "test.example.com",
+						IngressRuleValue: networkingv1.IngressRuleValue{
+							HTTP: &networkingv1.HTTPIngressRuleValue{
+								Paths: []networkingv1.HTTPIngressPath{{
+									Path:     "/",
+									PathType: &pathType,
+									Backend: networkingv1.IngressBackend{
+										Service: &networkingv1.IngressServiceBackend{
+											Name: "svc",
+											Port: networkingv1.ServiceBackendPort{Number: 80},
+										},
+									},
+								}},
+							},
+						},
+					}},
+					TLS: []networkingv1.IngressTLS{{
+						Hosts:      []string{"test.example.com"},
+						SecretName: "cert",
+					}},
+				},
+			}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := NewIngress(tNamespace, tName, tc.options...)
+			assert.Equal(t, &tc.expected, got)
+		})
+	}
+}
@@ -61,3 +61,33 @@ func RevisionSpecWithPodSpecOptions(opts ...PodSpecOption) RevisionTemplateOptio
 		}
 	}
 }
+
+func KnativeServiceAddTrafficTarget(tt servingv1.TrafficTarget) KnativeServiceOption {
+	return func(s *servingv1.Service) {
+		s.Spec.Traffic = append(s.Spec.Traffic, tt)
+	}
+}
+
+func RevisionWithName(name string) RevisionTemplateOption {
+	return func(rts *servingv1.RevisionTemplateSpec) {
+		rts.Name = name
+	}
+}
+
+func RevisionSpecWithContainerConcurrency(cc int64) RevisionTemplateOption {
+	return func(rts *servingv1.RevisionTemplateSpec) {
+		rts.Spec.ContainerConcurrency = &cc
+	}
+}
+
+func RevisionSpecWithTimeoutSeconds(seconds int64) RevisionTemplateOption {
+	return func(rts *servingv1.RevisionTemplateSpec) {
+		rts.Spec.TimeoutSeconds = &seconds
+	}
+}
+
+func RevisionSpecWithResponseStartTimeoutSeconds(seconds int64) RevisionTemplateOption {
+	return func(rts *servingv1.RevisionTemplateSpec) {
+		rts.Spec.ResponseStartTimeoutSeconds = &seconds
+	}
+}
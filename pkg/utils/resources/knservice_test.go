@@ -0,0 +1,87 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+)
+
+func TestNewKnativeService(t *testing.T) {
+	latest := true
+	percent := int64(100)
+	cc := int64(10)
+	timeout := int64(60)
+	responseStart := int64(30)
+
+	testCases := map[string]struct {
+		options  []KnativeServiceOption
+		expected servingv1.Service
+	}{
+		"basic": {
+			expected: servingv1.Service{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Service",
+					APIVersion: servingv1.SchemeGroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: tNamespace,
+					Name:      tName,
+				},
+			}},
+		"with revision and traffic": {
+			options: []KnativeServiceOption{
+				KnativeServiceWithRevisionOptions(
+					RevisionWithName(tName+"-1"),
+					RevisionSpecWithContainerConcurrency(cc),
+					RevisionSpecWithTimeoutSeconds(timeout),
+					RevisionSpecWithResponseStartTimeoutSeconds(responseStart),
+				),
+				KnativeServiceAddTrafficTarget(servingv1.TrafficTarget{
+					LatestRevision: &latest,
+					Percent:        &percent,
+				}),
+			},
+			expected: servingv1.Service{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Service",
+					APIVersion: servingv1.SchemeGroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: tNamespace,
+					Name:      tName,
+				},
+				Spec: servingv1.ServiceSpec{
+					ConfigurationSpec: servingv1.ConfigurationSpec{
+						Template: servingv1.RevisionTemplateSpec{
+							ObjectMeta: metav1.ObjectMeta{
+								Name: tName + "-1",
+							},
+							Spec: servingv1.RevisionSpec{
+								ContainerConcurrency:        &cc,
+								TimeoutSeconds:              &timeout,
+								ResponseStartTimeoutSeconds: &responseStart,
+							},
+						},
+					},
+					RouteSpec: servingv1.RouteSpec{
+						Traffic: []servingv1.TrafficTarget{{
+							LatestRevision: &latest,
+							Percent:        &percent,
+						}},
+					},
+				},
+			}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := NewKnativeService(tNamespace, tName, tc.options...)
+			assert.Equal(t, &tc.expected, got)
+		})
+	}
+}
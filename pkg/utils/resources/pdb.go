@@ -0,0 +1,62 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+type PodDisruptionBudgetOption func(*policyv1.PodDisruptionBudget)
+
+func NewPodDisruptionBudget(namespace, name string, opts ...PodDisruptionBudgetOption) *policyv1.PodDisruptionBudget {
+	meta := NewMeta(namespace, name)
+	p := &policyv1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PodDisruptionBudget",
+			APIVersion: policyv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: *meta,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+func PodDisruptionBudgetWithMetaOptions(opts ...MetaOption) PodDisruptionBudgetOption {
+	return func(p *policyv1.PodDisruptionBudget) {
+		for _, opt := range opts {
+			opt(&p.ObjectMeta)
+		}
+	}
+}
+
+func PodDisruptionBudgetAddSelectorLabel(key, value string) PodDisruptionBudgetOption {
+	return func(p *policyv1.PodDisruptionBudget) {
+		if p.Spec.Selector == nil {
+			p.Spec.Selector = &metav1.LabelSelector{}
+		}
+		if p.Spec.Selector.MatchLabels == nil {
+			p.Spec.Selector.MatchLabels = make(map[string]string, 1)
+		}
+
+		p.Spec.Selector.MatchLabels[key] = value
+	}
+}
+
+func PodDisruptionBudgetWithMinAvailable(v *intstr.IntOrString) PodDisruptionBudgetOption {
+	return func(p *policyv1.PodDisruptionBudget) {
+		p.Spec.MinAvailable = v
+	}
+}
+
+func PodDisruptionBudgetWithMaxUnavailable(v *intstr.IntOrString) PodDisruptionBudgetOption {
+	return func(p *policyv1.PodDisruptionBudget) {
+		p.Spec.MaxUnavailable = v
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type RoleOption func(*rbacv1.Role)
+
+func NewRole(namespace, name string, opts ...RoleOption) *rbacv1.Role {
+	meta := NewMeta(namespace, name)
+	r := &rbacv1.Role{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Role",
+			APIVersion: rbacv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: *meta,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+func RoleWithMetaOptions(opts ...MetaOption) RoleOption {
+	return func(r *rbacv1.Role) {
+		for _, opt := range opts {
+			opt(&r.ObjectMeta)
+		}
+	}
+}
+
+func RoleWithRules(rules ...rbacv1.PolicyRule) RoleOption {
+	return func(r *rbacv1.Role) {
+		r.Rules = rules
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewRole(t *testing.T) {
+	rule := rbacv1.PolicyRule{
+		APIGroups: []string{""},
+		Resources: []string{"secrets"},
+		Verbs:     []string{"get"},
+	}
+
+	testCases := map[string]struct {
+		options  []RoleOption
+		expected rbacv1.Role
+	}{
+		"basic": {
+			expected: rbacv1.Role{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Role",
+					APIVersion: rbacv1.SchemeGroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: tNamespace,
+					Name:      tRoleName,
+				},
+			}},
+		"with rules": {
+			options: []RoleOption{
+				RoleWithMetaOptions(MetaAddLabel("key", "value")),
+				RoleWithRules(rule),
+			},
+			expected: rbacv1.Role{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Role",
+					APIVersion: rbacv1.SchemeGroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: tNamespace,
+					Name:      tRoleName,
+					Labels: map[string]string{
+						"key": "value",
+					},
+				},
+				Rules: []rbacv1.PolicyRule{rule},
+			}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := NewRole(tNamespace, tRoleName, tc.options...)
+			assert.Equal(t, &tc.expected, got)
+		})
+	}
+
+}
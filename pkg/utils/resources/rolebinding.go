@@ -49,3 +49,11 @@ func RoleBindingWithMetaOptions(opts ...MetaOption) RoleBindingOption {
 		}
 	}
 }
+
+// RoleBindingWithRole references a namespaced Role instead of a ClusterRole.
+func RoleBindingWithRole(name string) RoleBindingOption {
+	return func(rb *rbacv1.RoleBinding) {
+		rb.RoleRef.Kind = "Role"
+		rb.RoleRef.Name = name
+	}
+}
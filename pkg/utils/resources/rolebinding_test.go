@@ -70,6 +70,31 @@ func TestNewRoleBinding(t *testing.T) {
 					Name:      tServiceAccountName,
 				}},
 			}},
+		"with role": {
+			options: []RoleBindingOption{
+				RoleBindingWithRole("test-namespaced-role"),
+			},
+			expected: rbacv1.RoleBinding{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "RoleBinding",
+					APIVersion: rbacv1.SchemeGroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: tNamespace,
+					Name:      tRoleBindingName,
+				},
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: crGVK.Group,
+					Kind:     "Role",
+					Name:     "test-namespaced-role",
+				},
+				Subjects: []rbacv1.Subject{{
+					APIGroup:  saGVK.Group,
+					Kind:      saGVK.Kind,
+					Namespace: tNamespace,
+					Name:      tServiceAccountName,
+				}},
+			}},
 	}
 
 	for name, tc := range testCases {
@@ -44,3 +44,9 @@ func SecretSetData(key string, value []byte) SecretOption {
 		s.Data[key] = value
 	}
 }
+
+func SecretWithType(t corev1.SecretType) SecretOption {
+	return func(s *corev1.Secret) {
+		s.Type = t
+	}
+}
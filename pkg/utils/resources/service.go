@@ -65,3 +65,17 @@ func ServiceSetType(st corev1.ServiceType) ServiceOption {
 		s.Spec.Type = st
 	}
 }
+
+func ServiceAddPorts(ports ...corev1.ServicePort) ServiceOption {
+	return func(s *corev1.Service) {
+		s.Spec.Ports = append(s.Spec.Ports, ports...)
+	}
+}
+
+// ServiceSetClusterIP sets the cluster IP, use corev1.ClusterIPNone
+// for headless services.
+func ServiceSetClusterIP(ip string) ServiceOption {
+	return func(s *corev1.Service) {
+		s.Spec.ClusterIP = ip
+	}
+}
@@ -105,6 +105,31 @@ func TestNewService(t *testing.T) {
 					Type: corev1.ServiceTypeLoadBalancer,
 				},
 			}},
+		"headless with ports": {
+			options: []ServiceOption{
+				ServiceSetClusterIP(corev1.ClusterIPNone),
+				ServiceAddPorts(
+					corev1.ServicePort{Name: "grpc", Port: 9000, TargetPort: intstr.FromInt(9000)},
+					corev1.ServicePort{Name: "health", Port: 8080, TargetPort: intstr.FromInt(8081)},
+				),
+			},
+			expected: corev1.Service{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "Service",
+					APIVersion: corev1.SchemeGroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: tNamespace,
+					Name:      tName,
+				},
+				Spec: corev1.ServiceSpec{
+					ClusterIP: corev1.ClusterIPNone,
+					Ports: []corev1.ServicePort{
+						{Name: "grpc", Port: 9000, TargetPort: intstr.FromInt(9000)},
+						{Name: "health", Port: 8080, TargetPort: intstr.FromInt(8081)},
+					},
+				},
+			}},
 	}
 
 	for name, tc := range testCases {
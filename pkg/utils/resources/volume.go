@@ -59,3 +59,9 @@ func VolumeFromSecretSourceOption(svs *corev1.SecretVolumeSource) VolumeOption {
 		v.Secret = svs
 	}
 }
+
+func VolumeFromEmptyDirOption() VolumeOption {
+	return func(v *corev1.Volume) {
+		v.EmptyDir = &corev1.EmptyDirVolumeSource{}
+	}
+}
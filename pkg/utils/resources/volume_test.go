@@ -36,6 +36,16 @@ func TestNewVolume(t *testing.T) {
 					},
 				},
 			}},
+		"with empty dir": {
+			options: []VolumeOption{
+				VolumeFromEmptyDirOption(),
+			},
+			expected: corev1.Volume{
+				Name: tName,
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				},
+			}},
 	}
 
 	for name, tc := range testCases {
@@ -5,9 +5,14 @@ package semantic
 
 import (
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/conversion"
 
 	"knative.dev/networking/pkg/apis/networking"
@@ -29,7 +34,11 @@ var Semantic = conversion.EqualitiesOrDie(
 	serviceEqual,
 	knServiceEqual,
 	serviceAccountEqual,
+	horizontalPodAutoscalerEqual,
+	podDisruptionBudgetEqual,
+	ingressEqual,
 	statusEqual,
+	unstructuredEqual,
 )
 
 // eq is an instance of Equalities for internal deep derivative comparisons
@@ -120,6 +129,132 @@ func deploymentEqual(a, b *appsv1.Deployment) bool {
 		return false
 	}
 
+	if !containersOwnedEqual(a.Spec.Template.Spec.Containers, b.Spec.Template.Spec.Containers) ||
+		!containersOwnedEqual(a.Spec.Template.Spec.InitContainers, b.Spec.Template.Spec.InitContainers) {
+		return false
+	}
+
+	if !imagePullSecretsEqual(a.Spec.Template.Spec.ImagePullSecrets, b.Spec.Template.Spec.ImagePullSecrets) {
+		return false
+	}
+
+	if !podSchedulingEqual(&a.Spec.Template.Spec, &b.Spec.Template.Spec) {
+		return false
+	}
+
+	return true
+}
+
+// containersOwnedEqual compares the container settings managed by Scoby,
+// which can be removed from the registration or the instance and would not
+// be detected by the derivative comparison. Containers are matched by name.
+func containersOwnedEqual(a, b []corev1.Container) bool {
+	for i := range a {
+		var existing *corev1.Container
+		for j := range b {
+			if b[j].Name == a[i].Name {
+				existing = &b[j]
+				break
+			}
+		}
+		if existing == nil {
+			return false
+		}
+
+		if !resourceRequirementsEqual(&a[i].Resources, &existing.Resources) {
+			return false
+		}
+
+		if !probeEqual(a[i].LivenessProbe, existing.LivenessProbe) ||
+			!probeEqual(a[i].ReadinessProbe, existing.ReadinessProbe) ||
+			!probeEqual(a[i].StartupProbe, existing.StartupProbe) {
+			return false
+		}
+
+		if (a[i].Lifecycle == nil) != (existing.Lifecycle == nil) ||
+			!eq.DeepDerivative(a[i].Lifecycle, existing.Lifecycle) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// podSchedulingEqual compares the scheduling settings exactly, removed
+// settings are not detected by the derivative comparison.
+func podSchedulingEqual(a, b *corev1.PodSpec) bool {
+	if len(a.NodeSelector) != 0 || len(b.NodeSelector) != 0 {
+		if !equality.Semantic.DeepEqual(a.NodeSelector, b.NodeSelector) {
+			return false
+		}
+	}
+	if len(a.Tolerations) != 0 || len(b.Tolerations) != 0 {
+		if !equality.Semantic.DeepEqual(a.Tolerations, b.Tolerations) {
+			return false
+		}
+	}
+	if len(a.TopologySpreadConstraints) != 0 || len(b.TopologySpreadConstraints) != 0 {
+		if !equality.Semantic.DeepEqual(a.TopologySpreadConstraints, b.TopologySpreadConstraints) {
+			return false
+		}
+	}
+
+	return equality.Semantic.DeepEqual(a.Affinity, b.Affinity)
+}
+
+// imagePullSecretsEqual compares pull secrets exactly, removed secrets are
+// not detected by the derivative comparison.
+func imagePullSecretsEqual(a, b []corev1.LocalObjectReference) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Name != b[i].Name {
+			return false
+		}
+	}
+	return true
+}
+
+// probeEqual compares probes tolerating fields defaulted by the API
+// server, but not a removed probe.
+func probeEqual(a, b *corev1.Probe) bool {
+	if (a == nil) != (b == nil) {
+		return false
+	}
+	return eq.DeepDerivative(a, b)
+}
+
+// resourceRequirementsEqual compares limits and requests exactly. Requests
+// not informed are defaulted by the API server to the limits.
+func resourceRequirementsEqual(a, b *corev1.ResourceRequirements) bool {
+	if !resourceListEqual(a.Limits, b.Limits) {
+		return false
+	}
+
+	requests := make(corev1.ResourceList, len(a.Requests))
+	for k, v := range a.Requests {
+		requests[k] = v
+	}
+	for k, v := range a.Limits {
+		if _, ok := requests[k]; !ok {
+			requests[k] = v
+		}
+	}
+
+	return resourceListEqual(requests, b.Requests)
+}
+
+func resourceListEqual(a, b corev1.ResourceList) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		bv, ok := b[k]
+		if !ok || v.Cmp(bv) != 0 {
+			return false
+		}
+	}
 	return true
 }
 
@@ -136,7 +271,24 @@ func serviceEqual(a, b *corev1.Service) bool {
 		return false
 	}
 
-	if !eq.DeepDerivative(&a.Spec, &b.Spec) {
+	// Removed ports are not detected by the derivative comparison.
+	if len(a.Spec.Ports) != 0 && len(a.Spec.Ports) != len(b.Spec.Ports) {
+		return false
+	}
+
+	// Node ports allocated by the API server are not informed at
+	// the desired state.
+	spec := &a.Spec
+	for i := range spec.Ports {
+		if spec.Ports[i].NodePort == 0 && b.Spec.Ports[i].NodePort != 0 {
+			if spec == &a.Spec {
+				spec = a.Spec.DeepCopy()
+			}
+			spec.Ports[i].NodePort = b.Spec.Ports[i].NodePort
+		}
+	}
+
+	if !eq.DeepDerivative(spec, &b.Spec) {
 		return false
 	}
 
@@ -160,6 +312,24 @@ func knServiceEqual(a, b *servingv1.Service) bool {
 		return false
 	}
 
+	if !imagePullSecretsEqual(a.Spec.Template.Spec.ImagePullSecrets, b.Spec.Template.Spec.ImagePullSecrets) {
+		return false
+	}
+
+	if !podSchedulingEqual(&a.Spec.Template.Spec.PodSpec, &b.Spec.Template.Spec.PodSpec) {
+		return false
+	}
+
+	// Fields defaulted by Knative at traffic targets are not informed
+	// at the desired state and ignored by the derivative comparison,
+	// but removed targets must be detected.
+	if len(a.Spec.Traffic) != 0 && len(a.Spec.Traffic) != len(b.Spec.Traffic) {
+		return false
+	}
+	if !eq.DeepDerivative(&a.Spec.Traffic, &b.Spec.Traffic) {
+		return false
+	}
+
 	return true
 }
 
@@ -189,6 +359,72 @@ func serviceAccountEqual(a, b *corev1.ServiceAccount) bool {
 	return true
 }
 
+// horizontalPodAutoscalerEqual returns whether two HorizontalPodAutoscalers
+// are semantically equivalent.
+func horizontalPodAutoscalerEqual(a, b *autoscalingv2.HorizontalPodAutoscaler) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+
+	if !eq.DeepDerivative(&a.ObjectMeta, &b.ObjectMeta) {
+		return false
+	}
+
+	if !eq.DeepDerivative(&a.Spec, &b.Spec) {
+		return false
+	}
+
+	return true
+}
+
+// podDisruptionBudgetEqual returns whether two PodDisruptionBudgets are
+// semantically equivalent.
+func podDisruptionBudgetEqual(a, b *policyv1.PodDisruptionBudget) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+
+	if !eq.DeepDerivative(&a.ObjectMeta, &b.ObjectMeta) {
+		return false
+	}
+
+	if !eq.DeepDerivative(&a.Spec, &b.Spec) {
+		return false
+	}
+
+	return true
+}
+
+func ingressEqual(a, b *networkingv1.Ingress) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+
+	if !eq.DeepDerivative(&a.ObjectMeta, &b.ObjectMeta) {
+		return false
+	}
+
+	// Removing TLS is not detected by the derivative comparison.
+	if len(a.Spec.TLS) != len(b.Spec.TLS) {
+		return false
+	}
+
+	if !eq.DeepDerivative(&a.Spec, &b.Spec) {
+		return false
+	}
+
+	return true
+}
+
 func statusEqual(a, b *commonv1alpha1.Status) bool {
 	if a == b {
 		return true
@@ -203,3 +439,42 @@ func statusEqual(a, b *commonv1alpha1.Status) bool {
 
 	return true
 }
+
+// unstructuredEqual returns whether two unstructured objects are semantically
+// equivalent. Object status and metadata elements other than labels,
+// annotations and owner references are not compared.
+func unstructuredEqual(a, b *unstructured.Unstructured) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+
+	if a.GroupVersionKind() != b.GroupVersionKind() {
+		return false
+	}
+
+	if !eq.DeepDerivative(a.GetLabels(), b.GetLabels()) {
+		return false
+	}
+	if !eq.DeepDerivative(a.GetAnnotations(), b.GetAnnotations()) {
+		return false
+	}
+	if !eq.DeepDerivative(a.GetOwnerReferences(), b.GetOwnerReferences()) {
+		return false
+	}
+
+	for k := range a.Object {
+		switch k {
+		case "apiVersion", "kind", "metadata", "status":
+			continue
+		}
+
+		if !eq.DeepDerivative(a.Object[k], b.Object[k]) {
+			return false
+		}
+	}
+
+	return true
+}
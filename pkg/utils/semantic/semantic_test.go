@@ -6,6 +6,7 @@ package semantic
 import (
 	"encoding/json"
 	"os"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -13,7 +14,10 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"knative.dev/pkg/ptr"
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
@@ -25,6 +29,33 @@ const (
 	fixtureServiceAccountPath = "./testdata/serviceAccount.json"
 )
 
+// tSchedulingSettings informs each scheduling setting at a pod spec.
+var tSchedulingSettings = map[string]func(*corev1.PodSpec){
+	"nodeSelector": func(ps *corev1.PodSpec) {
+		ps.NodeSelector = map[string]string{"kubernetes.io/arch": "arm64"}
+	},
+	"tolerations": func(ps *corev1.PodSpec) {
+		ps.Tolerations = []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}}
+	},
+	"affinity": func(ps *corev1.PodSpec) {
+		ps.Affinity = &corev1.Affinity{
+			PodAntiAffinity: &corev1.PodAntiAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+					Weight:          100,
+					PodAffinityTerm: corev1.PodAffinityTerm{TopologyKey: "kubernetes.io/hostname"},
+				}},
+			},
+		}
+	},
+	"topologySpreadConstraints": func(ps *corev1.PodSpec) {
+		ps.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{{
+			MaxSkew:           1,
+			TopologyKey:       "topology.kubernetes.io/zone",
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+		}}
+	},
+}
+
 func TestDeploymentEqual(t *testing.T) {
 	current := &appsv1.Deployment{}
 	loadFixture(t, fixtureDeploymentPath, current)
@@ -96,6 +127,60 @@ func TestDeploymentEqual(t *testing.T) {
 			},
 			false,
 		},
+		"equal when probes, ports and resources omit defaulted fields": {
+			func() *appsv1.Deployment {
+				desired := current.DeepCopy()
+				c := &desired.Spec.Template.Spec.Containers[0]
+				c.ReadinessProbe = &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						HTTPGet: &corev1.HTTPGetAction{
+							Path: "/health",
+							Port: intstr.FromString("health"),
+						},
+					},
+				}
+				for i := range c.Ports {
+					c.Ports[i].Protocol = ""
+				}
+				c.Resources.Limits[corev1.ResourceCPU] = resource.MustParse("1000m")
+				return desired
+			},
+			true,
+		},
+		"not equal when probe desired field differs": {
+			func() *appsv1.Deployment {
+				desired := current.DeepCopy()
+				desired.Spec.Template.Spec.Containers[0].ReadinessProbe.PeriodSeconds = 5
+				return desired
+			},
+			false,
+		},
+		"not equal when removed override triggers update": {
+			func() *appsv1.Deployment {
+				desired := current.DeepCopy()
+				c := &desired.Spec.Template.Spec.Containers[0]
+				c.Resources = corev1.ResourceRequirements{}
+				c.ReadinessProbe = nil
+				return desired
+			},
+			false,
+		},
+		"not equal when resources are removed": {
+			func() *appsv1.Deployment {
+				desired := current.DeepCopy()
+				desired.Spec.Template.Spec.Containers[0].Resources = corev1.ResourceRequirements{}
+				return desired
+			},
+			false,
+		},
+		"not equal when probe is removed": {
+			func() *appsv1.Deployment {
+				desired := current.DeepCopy()
+				desired.Spec.Template.Spec.Containers[0].ReadinessProbe = nil
+				return desired
+			},
+			false,
+		},
 	}
 
 	for name, tc := range testCases {
@@ -110,6 +195,43 @@ func TestDeploymentEqual(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("not equal when existing pull secrets are removed", func(t *testing.T) {
+		existing := current.DeepCopy()
+		existing.Spec.Template.Spec.ImagePullSecrets = append(existing.Spec.Template.Spec.ImagePullSecrets,
+			corev1.LocalObjectReference{Name: "registry-credentials"})
+		assert.False(t, deploymentEqual(current, existing))
+	})
+
+	for name, schedule := range tSchedulingSettings {
+		//nolint:scopelint
+		t.Run("not equal when "+name+" is removed", func(t *testing.T) {
+			existing := current.DeepCopy()
+			schedule(&existing.Spec.Template.Spec)
+			assert.False(t, deploymentEqual(current, existing))
+			assert.True(t, deploymentEqual(existing.DeepCopy(), existing))
+		})
+	}
+
+	t.Run("not equal when lifecycle is removed", func(t *testing.T) {
+		existing := current.DeepCopy()
+		existing.Spec.Template.Spec.Containers[0].Lifecycle = &corev1.Lifecycle{
+			PreStop: &corev1.LifecycleHandler{
+				Exec: &corev1.ExecAction{Command: []string{"/bin/sleep", "5"}},
+			},
+		}
+		assert.False(t, deploymentEqual(current, existing))
+	})
+
+	t.Run("equal when requests are defaulted from limits", func(t *testing.T) {
+		desired := current.DeepCopy()
+		delete(desired.Spec.Template.Spec.Containers[0].Resources.Requests, corev1.ResourceCPU)
+
+		existing := current.DeepCopy()
+		existing.Spec.Template.Spec.Containers[0].Resources.Requests[corev1.ResourceCPU] = resource.MustParse("1")
+		assert.True(t, deploymentEqual(desired, existing))
+		assert.False(t, deploymentEqual(desired, current))
+	})
 }
 
 func TestKnServiceEqual(t *testing.T) {
@@ -171,6 +293,28 @@ func TestKnServiceEqual(t *testing.T) {
 			},
 			false,
 		},
+		"equal when traffic defaults are not informed": {
+			func() *servingv1.Service {
+				desired := current.DeepCopy()
+				desired.Spec.Traffic = []servingv1.TrafficTarget{{
+					LatestRevision: desired.Spec.Traffic[0].LatestRevision,
+				}}
+				return desired
+			},
+			true,
+		},
+		"not equal when traffic targets differ": {
+			func() *servingv1.Service {
+				desired := current.DeepCopy()
+				percent := int64(50)
+				desired.Spec.Traffic = []servingv1.TrafficTarget{
+					{RevisionName: "sample-1", Percent: &percent},
+					{LatestRevision: desired.Spec.Traffic[0].LatestRevision, Percent: &percent},
+				}
+				return desired
+			},
+			false,
+		},
 	}
 
 	for name, tc := range testCases {
@@ -185,6 +329,70 @@ func TestKnServiceEqual(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("not equal when existing pull secrets are removed", func(t *testing.T) {
+		existing := current.DeepCopy()
+		existing.Spec.Template.Spec.ImagePullSecrets = append(existing.Spec.Template.Spec.ImagePullSecrets,
+			corev1.LocalObjectReference{Name: "registry-credentials"})
+		assert.False(t, knServiceEqual(current, existing))
+	})
+
+	for name, schedule := range tSchedulingSettings {
+		//nolint:scopelint
+		t.Run("not equal when "+name+" is removed", func(t *testing.T) {
+			existing := current.DeepCopy()
+			schedule(&existing.Spec.Template.Spec.PodSpec)
+			assert.False(t, knServiceEqual(current, existing))
+			assert.True(t, knServiceEqual(existing.DeepCopy(), existing))
+		})
+	}
+}
+
+func TestServiceEqual(t *testing.T) {
+	newService := func(nodePorts ...int32) *corev1.Service {
+		s := &corev1.Service{
+			Spec: corev1.ServiceSpec{
+				Type: corev1.ServiceTypeNodePort,
+			},
+		}
+		for i, np := range nodePorts {
+			s.Spec.Ports = append(s.Spec.Ports, corev1.ServicePort{
+				Name:       "port" + strconv.Itoa(i),
+				Port:       int32(8080 + i),
+				TargetPort: intstr.FromInt(8080 + i),
+				NodePort:   np,
+			})
+		}
+		return s
+	}
+
+	current := newService(30080, 30081)
+
+	testCases := map[string]struct {
+		desired *corev1.Service
+		expect  bool
+	}{
+		"equal when node ports are allocated": {
+			desired: newService(0, 0),
+			expect:  true,
+		},
+		"not equal when node port differs": {
+			desired: newService(0, 30000),
+			expect:  false,
+		},
+		"not equal when a port is removed": {
+			desired: newService(0),
+			expect:  false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expect, serviceEqual(tc.desired, current))
+			// The desired object is not modified.
+			assert.Zero(t, tc.desired.Spec.Ports[0].NodePort)
+		})
+	}
 }
 
 func TestServiceAccountEqual(t *testing.T) {
@@ -272,6 +480,87 @@ func TestServiceAccountEqual(t *testing.T) {
 	}
 }
 
+func TestUnstructuredEqual(t *testing.T) {
+	current := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":            "test",
+				"namespace":       "test",
+				"resourceVersion": "123",
+				"labels": map[string]interface{}{
+					"key1": "value1",
+					"key2": "value2",
+				},
+			},
+			"data": map[string]interface{}{
+				"key": "value",
+			},
+		},
+	}
+
+	assert.True(t, unstructuredEqual(nil, nil), "Two nil elements should be equal")
+
+	testCases := map[string]struct {
+		prep   func() *unstructured.Unstructured
+		expect bool
+	}{
+		"not equal when one element is nil": {
+			func() *unstructured.Unstructured {
+				return nil
+			},
+			false,
+		},
+		"equal when current has more metadata than desired": {
+			func() *unstructured.Unstructured {
+				desired := current.DeepCopy()
+				unstructured.RemoveNestedField(desired.Object, "metadata", "resourceVersion")
+				unstructured.RemoveNestedField(desired.Object, "metadata", "labels", "key2")
+				return desired
+			},
+			true,
+		},
+		"not equal when kinds differ": {
+			func() *unstructured.Unstructured {
+				desired := current.DeepCopy()
+				desired.SetKind("Secret")
+				return desired
+			},
+			false,
+		},
+		"not equal when some existing attribute differs": {
+			func() *unstructured.Unstructured {
+				desired := current.DeepCopy()
+				_ = unstructured.SetNestedField(desired.Object, "other", "data", "key")
+				return desired
+			},
+			false,
+		},
+		"not equal when desired has more attributes than current": {
+			func() *unstructured.Unstructured {
+				desired := current.DeepCopy()
+				_ = unstructured.SetNestedField(desired.Object, "value", "data", "newkey")
+				return desired
+			},
+			false,
+		},
+	}
+
+	for name, tc := range testCases {
+		//nolint:scopelint
+		t.Run(name, func(t *testing.T) {
+			desired := tc.prep()
+			switch tc.expect {
+			case true:
+				assert.True(t, unstructuredEqual(desired, current))
+			case false:
+				assert.False(t, unstructuredEqual(desired, current))
+			}
+		})
+	}
+}
+
 func loadFixture(t *testing.T, file string, obj runtime.Object) {
 	t.Helper()
 